@@ -1,7 +1,15 @@
 package main
 
 import (
+	"context"
+	"io"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // часовые пояса для /daily, даже если в системе нет tzdata
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
@@ -10,10 +18,20 @@ import (
 	"github.com/RZ-ru/Inshakerov_bot/internal/db"
 )
 
+// shutdownTimeout — сколько ждать обработки начатых апдейтов после SIGINT/SIGTERM
+const shutdownTimeout = 30 * time.Second
+
 func main() {
+	// Ошибки из лога дополнительно копятся для /admin errors
+	log.SetOutput(io.MultiWriter(os.Stderr, bot.RecentErrors))
+
 	// 1️⃣ Загружаем конфигурацию (.env)
 	cfg := config.Load()
 
+	// ctx отменяется по SIGINT/SIGTERM: перестаём принимать апдейты
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// 2️⃣ Подключаемся к PostgreSQL
 	database := db.Connect(cfg.DBUrl)
 	if database == nil {
@@ -22,35 +40,109 @@ func main() {
 	defer database.Close()
 	log.Println("📡 Подключение к базе установлено")
 
-	// 3️⃣ Инициализируем Telegram-бота
-	botAPI, err := tgbotapi.NewBotAPI(cfg.BotToken)
+	if err := db.Migrate(ctx, database); err != nil {
+		log.Fatalf("❌ Ошибка миграции базы: %v", err)
+	}
+	if err := db.SeedTaxonomy(ctx, database); err != nil {
+		log.Printf("⚠️ Ошибка построения иерархии ингредиентов: %v", err)
+	}
+	if err := db.SeedAliases(ctx, database); err != nil {
+		log.Printf("⚠️ Ошибка загрузки синонимов: %v", err)
+	}
+	if err := db.SeedSubstitutions(ctx, database); err != nil {
+		log.Printf("⚠️ Ошибка загрузки замен ингредиентов: %v", err)
+	}
+	if err := db.EnsureSimilarity(ctx, database); err != nil {
+		log.Printf("⚠️ Ошибка расчёта похожих коктейлей: %v", err)
+	}
+
+	// 3️⃣ Инициализируем Telegram-бота. Все запросы идут через Sender:
+	// он соблюдает лимиты Telegram и повторяет отправку после 429
+	sender := bot.NewSender(90 * time.Second)
+	sender.OnBlocked = func(chatID int64) {
+		if err := db.SetUserBlocked(context.Background(), database, chatID, true); err != nil {
+			log.Printf("⚠️ Ошибка отметки пользователя %d: %v", chatID, err)
+		}
+	}
+	endpoint := tgbotapi.APIEndpoint
+	if cfg.APIEndpoint != "" {
+		endpoint = cfg.APIEndpoint
+	}
+	botAPI, err := tgbotapi.NewBotAPIWithClient(cfg.BotToken, endpoint, sender)
 	if err != nil {
 		log.Fatalf("❌ Ошибка запуска бота: %v", err)
 	}
 	botAPI.Debug = false
 	log.Printf("🤖 Бот запущен как %s", botAPI.Self.UserName)
 
-	// 4️⃣ Настраиваем получение обновлений
-	u := tgbotapi.NewUpdate(0)
-	u.Timeout = 60
-
-	updates := botAPI.GetUpdatesChan(u)
-
-	// 5️⃣ Основной цикл
-	for update := range updates {
-		if update.Message != nil {
-			switch {
-			case update.Message.IsCommand():
-				switch update.Message.Command() {
-				case "start":
-					bot.HandleStart(botAPI, update)
-				}
-			default:
-				bot.HandleIngredientInput(botAPI, update, database)
+	// Планировщик "коктейля дня"
+	go bot.RunDailyScheduler(ctx, botAPI, database)
+	bot.ResumeBroadcasts(ctx, botAPI, database)
+
+	// workCtx живёт дольше ctx: начатые апдейты дорабатываются после сигнала
+	// и прерываются, только если не уложились в shutdownTimeout
+	workCtx, cancelWork := context.WithCancel(context.Background())
+	defer cancelWork()
+
+	// Апдейты разных чатов обрабатываются параллельно, одного чата — по порядку
+	pool := bot.NewPool(cfg.Workers, cfg.WorkerQueue, func(update tgbotapi.Update) {
+		bot.Dispatch(workCtx, botAPI, update, database, cfg)
+	})
+	go pool.LogStats(time.Minute)
+
+	if cfg.Mode == config.ModeWebhook {
+		// 4️⃣ Webhook: Telegram сам присылает апдейты на наш HTTP-сервер
+		if err := bot.SetWebhook(botAPI, cfg); err != nil {
+			log.Fatalf("❌ Ошибка регистрации webhook: %v", err)
+		}
+		log.Printf("🔗 Webhook зарегистрирован: %s", cfg.WebhookURL)
+		handler := bot.WebhookHandler(cfg.WebhookSecret, pool.Submit)
+		if err := bot.ServeWebhook(ctx, cfg, handler, shutdownTimeout); err != nil && err != http.ErrServerClosed {
+			log.Printf("❌ Ошибка webhook-сервера: %v", err)
+		}
+	} else {
+		// 4️⃣ Long polling: снимаем webhook, если он остался от прошлого запуска
+		if err := bot.DeleteWebhook(botAPI); err != nil {
+			log.Printf("⚠️ Ошибка удаления webhook: %v", err)
+		}
+		u := tgbotapi.NewUpdate(0)
+		u.Timeout = 60
+		u.AllowedUpdates = bot.AllowedUpdates
+
+		updates := botAPI.GetUpdatesChan(u)
+
+		// 5️⃣ Основной цикл. Апдейты, полученные, но не переданные в pool,
+		// Telegram доставит повторно: их offset ещё не подтверждён
+	loop:
+		for {
+			select {
+			case <-ctx.Done():
+				botAPI.StopReceivingUpdates()
+				break loop
+			case update := <-updates:
+				pool.Submit(update)
 			}
-		} else if update.CallbackQuery != nil {
-			bot.HandleIngredientConfirm(botAPI, update, database)
-			bot.HandleCallback(botAPI, update, database)
+		}
+	}
+
+	// 6️⃣ Дорабатываем очередь и закрываем базу (defer)
+	log.Println("🛑 Остановка: дорабатываем начатые апдейты...")
+	drained := make(chan struct{})
+	go func() {
+		pool.Close()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		log.Println("✅ Все апдейты обработаны")
+	case <-time.After(shutdownTimeout):
+		log.Println("⚠️ Не уложились в таймаут — прерываем обработку")
+		cancelWork()
+		sender.Stop()
+		select {
+		case <-drained:
+		case <-time.After(5 * time.Second):
+			log.Println("⚠️ Часть апдейтов не завершилась — выходим")
 		}
 	}
 }
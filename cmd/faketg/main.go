@@ -0,0 +1,161 @@
+// faketg — фейковый Bot API для локальной проверки webhook-режима.
+// Отвечает на методы бота и печатает исходящие сообщения, а строки из stdin
+// отправляет боту как апдейты на зарегистрированный webhook:
+//
+//	go run ./cmd/faketg
+//	TELEGRAM_API_ENDPOINT=http://localhost:8081/bot%s/%s BOT_MODE=webhook \
+//	WEBHOOK_URL=http://localhost:8080/ WEBHOOK_SECRET=local go run ./cmd/bot
+//
+// Строка "/find лайм" — команда, "cb:fav_12" — нажатие кнопки,
+// "@негрони" — inline-запрос, остальное — текст.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type server struct {
+	mu      sync.Mutex
+	webhook string
+	secret  string
+	nextID  int
+}
+
+func main() {
+	listen := flag.String("listen", ":8081", "адрес фейкового Bot API")
+	userID := flag.Int64("user", 1001, "ID пользователя, от имени которого пишем боту")
+	flag.Parse()
+
+	s := &server{}
+	go func() {
+		log.Printf("🧪 Фейковый Bot API на %s", *listen)
+		log.Fatal(http.ListenAndServe(*listen, s))
+	}()
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := s.post(s.update(*userID, line)); err != nil {
+			log.Println("❌", err)
+		}
+	}
+}
+
+// ServeHTTP — /bot<token>/<method>
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	method := parts[len(parts)-1]
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		r.ParseForm()
+	}
+
+	var result any = true
+	switch method {
+	case "getMe":
+		result = map[string]any{"id": 1, "is_bot": true, "first_name": "Fake", "username": "fake_bot"}
+	case "setWebhook":
+		s.mu.Lock()
+		s.webhook, s.secret = r.FormValue("url"), r.FormValue("secret_token")
+		s.mu.Unlock()
+		log.Printf("🔗 setWebhook %s", r.FormValue("url"))
+	case "getUpdates":
+		time.Sleep(time.Second)
+		result = []any{}
+	case "answerInlineQuery":
+		fmt.Printf("\n🤖 [%s]\n%s\n", method, r.FormValue("results"))
+		if pm := r.FormValue("switch_pm_text"); pm != "" {
+			fmt.Printf("💬 %s\n", pm)
+		}
+	case "sendMessage", "sendPhoto", "sendDocument", "editMessageText", "editMessageReplyMarkup":
+		text := r.FormValue("text") + r.FormValue("caption")
+		fmt.Printf("\n🤖 [%s → %s]\n%s\n", method, r.FormValue("chat_id"), text)
+		if markup := r.FormValue("reply_markup"); markup != "" {
+			fmt.Printf("⌨️  %s\n", markup)
+		}
+		s.mu.Lock()
+		s.nextID++
+		id := s.nextID
+		s.mu.Unlock()
+		var chatID int64
+		fmt.Sscan(r.FormValue("chat_id"), &chatID)
+		result = map[string]any{"message_id": id, "date": time.Now().Unix(), "chat": map[string]any{"id": chatID, "type": "private"}}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+}
+
+// update — апдейт из строки ввода
+func (s *server) update(userID int64, line string) map[string]any {
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	s.mu.Unlock()
+
+	from := map[string]any{"id": userID, "is_bot": false, "first_name": "Tester", "language_code": "ru"}
+	chat := map[string]any{"id": userID, "type": "private"}
+
+	if data, ok := strings.CutPrefix(line, "cb:"); ok {
+		return map[string]any{"update_id": id, "callback_query": map[string]any{
+			"id": fmt.Sprint(id), "from": from, "data": data, "chat_instance": "local",
+			"message": map[string]any{"message_id": id, "date": time.Now().Unix(), "chat": chat},
+		}}
+	}
+
+	if query, ok := strings.CutPrefix(line, "@"); ok {
+		return map[string]any{"update_id": id, "inline_query": map[string]any{
+			"id": fmt.Sprint(id), "from": from, "query": query, "offset": "",
+		}}
+	}
+
+	msg := map[string]any{"message_id": id, "date": time.Now().Unix(), "from": from, "chat": chat, "text": line}
+	if strings.HasPrefix(line, "/") {
+		cmd, _, _ := strings.Cut(line, " ")
+		msg["entities"] = []any{map[string]any{"type": "bot_command", "offset": 0, "length": len(cmd)}}
+	}
+	return map[string]any{"update_id": id, "message": msg}
+}
+
+// post — доставляет апдейт на webhook так же, как Telegram
+func (s *server) post(update map[string]any) error {
+	s.mu.Lock()
+	webhook, secret := s.webhook, s.secret
+	s.mu.Unlock()
+	if webhook == "" {
+		return fmt.Errorf("webhook ещё не зарегистрирован")
+	}
+
+	body, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("webhook ответил HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/config"
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	"github.com/RZ-ru/Inshakerov_bot/internal/scraper"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// HandleAlias — /alias водочка = Водка: добавить синоним ингредиента (только для админов)
+func HandleAlias(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB, cfg *config.Config) {
+	chatID := update.Message.Chat.ID
+	lang := langOf(update.Message.From.ID)
+	if !cfg.IsAdmin(update.Message.From.ID) {
+		send(bot, chatID, i18n.T(lang, "admin.only"))
+		return
+	}
+
+	alias, name, ok := strings.Cut(update.Message.CommandArguments(), "=")
+	alias, name = strings.TrimSpace(alias), strings.TrimSpace(name)
+	if !ok || alias == "" || name == "" {
+		send(bot, chatID, i18n.T(lang, "alias.usage"))
+		return
+	}
+
+	audit(ctx, database, update.Message.From.ID, "alias", update.Message.CommandArguments())
+	good, err := db.AddAlias(ctx, database, alias, name)
+	if err != nil {
+		log.Println("Ошибка добавления синонима:", err)
+		send(bot, chatID, i18n.T(lang, "alias.failed", err))
+		return
+	}
+	send(bot, chatID, i18n.T(lang, "alias.added", alias, good.Name))
+}
+
+const (
+	adminAuditLimit = 15   // сколько записей журнала показывать
+	messageLimit    = 4096 // ограничение Telegram на длину сообщения (в UTF-16)
+)
+
+// HandleAdmin — /admin [scrape|status|stats|aliases|errors|audit]: служебные команды.
+// Без аргумента — подсказка и кнопки
+func HandleAdmin(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB, cfg *config.Config) {
+	userID := update.Message.From.ID
+	if !cfg.IsAdmin(userID) {
+		send(bot, update.Message.Chat.ID, i18n.T(langOf(userID), "admin.only"))
+		return
+	}
+	runAdmin(ctx, bot, update.Message.Chat.ID, database, userID, strings.TrimSpace(update.Message.CommandArguments()))
+}
+
+// HandleAdminCallback — admin_<действие>: кнопки админки
+func HandleAdminCallback(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB, cfg *config.Config) {
+	cq := update.CallbackQuery
+	bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+	if !cfg.IsAdmin(cq.From.ID) {
+		send(bot, cq.Message.Chat.ID, i18n.T(langOf(cq.From.ID), "admin.only"))
+		return
+	}
+	runAdmin(ctx, bot, cq.Message.Chat.ID, database, cq.From.ID, strings.TrimPrefix(cq.Data, "admin_"))
+}
+
+// runAdmin — выполняет действие администратора и записывает его в журнал
+func runAdmin(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, adminID int64, args string) {
+	lang := langOf(adminID)
+	action, rest, _ := strings.Cut(args, " ")
+	action = strings.ToLower(action)
+	if action != "" {
+		audit(ctx, database, adminID, action, rest)
+	}
+
+	switch action {
+	case "":
+		msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "admin.help"))
+		msg.ReplyMarkup = AdminKeyboard(lang)
+		bot.Send(msg)
+
+	case "scrape":
+		// парсинг идёт в фоне дольше обработки апдейта; ctx обработчиков
+		// отменяется только при остановке бота
+		if !scraper.Start(ctx, database) {
+			send(bot, chatID, i18n.T(lang, "admin.scrape_running"))
+			return
+		}
+		send(bot, chatID, i18n.T(lang, "admin.scrape_started"))
+
+	case "status":
+		send(bot, chatID, formatScrapeStatus(scraper.GetStatus(), lang))
+
+	case "stats":
+		s, err := db.GetStats(ctx, database)
+		if err != nil {
+			log.Println("Ошибка получения статистики:", err)
+			send(bot, chatID, i18n.T(lang, "err.db"))
+			return
+		}
+		send(bot, chatID, i18n.T(lang, "admin.stats",
+			s.Cocktails, s.Translated, s.Goods, s.Aliases, s.Users, s.BlockedUsers, s.Favorites))
+
+	case "aliases":
+		if err := db.SeedAliases(ctx, database); err != nil {
+			log.Println("Ошибка перезагрузки синонимов:", err)
+			send(bot, chatID, i18n.T(lang, "err.db"))
+			return
+		}
+		s, err := db.GetStats(ctx, database)
+		if err != nil {
+			log.Println("Ошибка получения статистики:", err)
+		}
+		send(bot, chatID, i18n.T(lang, "admin.aliases_reloaded", s.Aliases))
+
+	case "errors":
+		lines := RecentErrors.Lines()
+		if len(lines) == 0 {
+			send(bot, chatID, i18n.T(lang, "admin.errors_none"))
+			return
+		}
+		send(bot, chatID, joinWithinLimit(i18n.T(lang, "admin.errors_title"), lines, "\n\n"))
+
+	case "audit":
+		actions, err := db.GetAdminActions(ctx, database, adminAuditLimit)
+		if err != nil {
+			log.Println("Ошибка получения журнала:", err)
+			send(bot, chatID, i18n.T(lang, "err.db"))
+			return
+		}
+		if len(actions) == 0 {
+			send(bot, chatID, i18n.T(lang, "admin.audit_none"))
+			return
+		}
+		var lines []string
+		for _, a := range actions {
+			lines = append(lines, fmt.Sprintf("%s · %d · %s %s",
+				a.CreatedAt.Format("02.01 15:04"), a.AdminID, a.Action, a.Args))
+		}
+		send(bot, chatID, joinWithinLimit(i18n.T(lang, "admin.audit_title"), lines, "\n"))
+
+	default:
+		send(bot, chatID, i18n.T(lang, "admin.unknown")+"\n\n"+i18n.T(lang, "admin.help"))
+	}
+}
+
+// joinWithinLimit — заголовок и записи через sep, пока сообщение укладывается
+// в messageLimit. Записи идут от новых к старым, поэтому отбрасываются самые старые
+func joinWithinLimit(title string, entries []string, sep string) string {
+	text := title
+	for _, e := range entries {
+		next := text + sep + e
+		if len(utf16.Encode([]rune(next))) > messageLimit {
+			break
+		}
+		text = next
+	}
+	return text
+}
+
+// audit — запись в журнал действий; ошибка журнала не мешает самому действию
+func audit(ctx context.Context, database *sql.DB, adminID int64, action, args string) {
+	if err := db.LogAdminAction(ctx, database, adminID, action, strings.TrimSpace(args)); err != nil {
+		log.Println("Ошибка записи в журнал администратора:", err)
+	}
+}
+
+// formatScrapeStatus — состояние парсинга для /admin status
+func formatScrapeStatus(s scraper.Status, lang i18n.Lang) string {
+	const layout = "02.01 15:04"
+	var lines []string
+	switch {
+	case s.StartedAt.IsZero():
+		return i18n.T(lang, "admin.status_never")
+	case s.Running:
+		lines = append(lines, i18n.T(lang, "admin.status_running", s.StartedAt.Format(layout), s.Recipes))
+	case s.Err != nil:
+		lines = append(lines, i18n.T(lang, "admin.status_failed", s.StartedAt.Format(layout), s.Err))
+	default:
+		lines = append(lines, i18n.T(lang, "admin.status_done",
+			s.StartedAt.Format(layout), s.FinishedAt.Format(layout), s.FinishedAt.Sub(s.StartedAt).Round(time.Second), s.Recipes))
+	}
+
+	locales := make([]string, 0, len(s.Translated))
+	for locale := range s.Translated {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	for _, locale := range locales {
+		lines = append(lines, i18n.T(lang, "admin.status_translated", locale, s.Translated[locale]))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestJoinWithinLimit(t *testing.T) {
+	long := strings.Repeat("я", 2000)
+	emoji := strings.Repeat("🍸", 1500) // каждый — две единицы UTF-16
+
+	tests := []struct {
+		name    string
+		title   string
+		entries []string
+		want    string
+	}{
+		{"пусто", "Ошибки:", nil, "Ошибки:"},
+		{"всё помещается", "Ошибки:", []string{"a", "b"}, "Ошибки:\na\nb"},
+		{"старые записи отбрасываются", "T", []string{long, long, long}, "T\n" + long + "\n" + long},
+		{"длина в UTF-16", "T", []string{emoji, emoji}, "T\n" + emoji},
+		{"запись длиннее лимита", "T", []string{strings.Repeat("x", messageLimit)}, "T"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := joinWithinLimit(tt.title, tt.entries, "\n")
+			if got != tt.want {
+				t.Errorf("joinWithinLimit() длиной %d; want длиной %d", len([]rune(got)), len([]rune(tt.want)))
+			}
+			if n := len(utf16.Encode([]rune(got))); n > messageLimit {
+				t.Errorf("длина %d больше messageLimit", n)
+			}
+		})
+	}
+}
+
+func TestErrorLog(t *testing.T) {
+	e := NewErrorLog(3)
+	for _, line := range []string{
+		"2024/01/01 ✅ Успешно сохранено 10 коктейлей\n",
+		"2024/01/01 Ошибка получения бара: timeout\n",
+		"2024/01/01 ⚠️ Рассылка #1 приостановлена\n",
+		"2024/01/01 🍸 обычная строка\n",
+		"2024/01/01 ❌ Ошибка завершения рассылки #1\n",
+		"2024/01/01 Ошибка поиска: x\n",
+	} {
+		if n, err := e.Write([]byte(line)); n != len(line) || err != nil {
+			t.Fatalf("Write() = %d, %v; want %d, nil", n, err, len(line))
+		}
+	}
+
+	want := []string{
+		"2024/01/01 Ошибка поиска: x",
+		"2024/01/01 ❌ Ошибка завершения рассылки #1",
+		"2024/01/01 ⚠️ Рассылка #1 приостановлена",
+	}
+	got := e.Lines()
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Lines() = %q; want %q", got, want)
+	}
+
+	e.Write([]byte("❌ " + strings.Repeat("ж", 500)))
+	if line := e.Lines()[0]; len([]rune(line)) != 301 || !strings.HasSuffix(line, "…") {
+		t.Errorf("длинная строка не обрезана: %d символов", len([]rune(line)))
+	}
+}
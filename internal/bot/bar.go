@@ -0,0 +1,232 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	makeableMaxMissing = 2  // показываем коктейли, где не хватает до двух ингредиентов
+	makeableLimit      = 30 // сколько коктейлей выводить в /make
+	categoryHintLimit  = 5  // сколько ингредиентов группы предлагать вместо неё
+)
+
+// HandleBar — /bar: показать домашний бар; /bar лайм, ром — добавить ингредиенты
+func HandleBar(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	lang := langOf(userID)
+
+	if args := strings.TrimSpace(update.Message.CommandArguments()); args != "" {
+		var added, unknown []string
+		for _, name := range strings.Split(args, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+
+			good, ok, err := db.FindGood(ctx, database, name)
+			if err != nil {
+				log.Println("Ошибка поиска ингредиента для бара:", err)
+				send(bot, chatID, i18n.T(lang, "err.db"))
+				return
+			}
+			if !ok {
+				unknown = append(unknown, name)
+				continue
+			}
+			if good.IsCategory {
+				send(bot, chatID, categoryHint(ctx, database, good, lang))
+				continue
+			}
+			if err := db.AddBarItem(ctx, database, userID, good.ID); err != nil {
+				log.Println("Ошибка добавления в бар:", err)
+				send(bot, chatID, i18n.T(lang, "bar.add_failed"))
+				return
+			}
+			added = append(added, good.Name)
+		}
+
+		var reply []string
+		if len(added) > 0 {
+			reply = append(reply, i18n.T(lang, "bar.added", strings.Join(added, ", ")))
+		}
+		if len(unknown) > 0 {
+			reply = append(reply, i18n.T(lang, "bar.unknown", strings.Join(unknown, ", ")))
+		}
+		if len(reply) > 0 {
+			send(bot, chatID, strings.Join(reply, "\n"))
+		}
+	}
+
+	showBar(ctx, bot, chatID, database, userID)
+}
+
+// categoryHint — служебный узел иерархии ("Цитрусовые") в бар не кладём:
+// предлагаем выбрать конкретные ингредиенты из этой группы
+func categoryHint(ctx context.Context, database *sql.DB, good db.Good, lang i18n.Lang) string {
+	children, err := db.GetGoodDescendants(ctx, database, good.ID)
+	if err != nil {
+		log.Println("Ошибка получения потомков ингредиента:", err)
+	}
+	var names []string
+	for _, c := range children {
+		if len(names) == categoryHintLimit {
+			break
+		}
+		names = append(names, c.Name)
+	}
+	return i18n.T(lang, "good.category", good.Name, strings.Join(names, ", "))
+}
+
+// showBar — выводит содержимое бара с кнопками удаления
+func showBar(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, userID int64) {
+	lang := langOf(userID)
+	goods, err := db.GetBar(ctx, database, userID)
+	if err != nil {
+		log.Println("Ошибка получения бара:", err)
+		send(bot, chatID, i18n.T(lang, "err.db"))
+		return
+	}
+
+	if len(goods) == 0 {
+		send(bot, chatID, i18n.T(lang, "bar.empty_hint"))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, i18n.N(lang, "bar.summary", len(goods)))
+	msg.ReplyMarkup = BarKeyboard(goods, 0)
+	bot.Send(msg)
+}
+
+// HandleBarCallback — bar_rm_<good_id>_<страница>: убрать ингредиент из бара,
+// bar_page_<страница>: листать список
+func HandleBarCallback(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	cq := update.CallbackQuery
+	chatID := cq.Message.Chat.ID
+	userID := cq.From.ID
+	lang := langOf(userID)
+
+	var page int
+	switch action := strings.TrimPrefix(cq.Data, "bar_"); {
+	case strings.HasPrefix(action, "page_"):
+		page, _ = strconv.Atoi(strings.TrimPrefix(action, "page_"))
+		bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+
+	case strings.HasPrefix(action, "rm_"):
+		rawID, rawPage, _ := strings.Cut(strings.TrimPrefix(action, "rm_"), "_")
+		goodID, err := strconv.Atoi(rawID)
+		if err != nil {
+			bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+			return
+		}
+		page, _ = strconv.Atoi(rawPage)
+
+		if err := db.RemoveBarItem(ctx, database, userID, goodID); err != nil {
+			log.Println("Ошибка удаления из бара:", err)
+			bot.Request(tgbotapi.NewCallback(cq.ID, i18n.T(lang, "bar.remove_failed")))
+			return
+		}
+		bot.Request(tgbotapi.NewCallback(cq.ID, i18n.T(lang, "bar.removed")))
+
+	default:
+		bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+		return
+	}
+
+	goods, err := db.GetBar(ctx, database, userID)
+	if err != nil {
+		log.Println("Ошибка получения бара:", err)
+		return
+	}
+	if len(goods) == 0 {
+		bot.Send(tgbotapi.NewEditMessageText(chatID, cq.Message.MessageID, i18n.T(lang, "bar.empty")))
+		return
+	}
+	bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, cq.Message.MessageID,
+		i18n.N(lang, "bar.summary", len(goods)), BarKeyboard(goods, page)))
+}
+
+// HandleMakeable — /make: что можно приготовить из домашнего бара
+func HandleMakeable(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	showMakeable(ctx, bot, update.Message.Chat.ID, database, update.Message.From.ID, false)
+}
+
+// HandleMakeableCallback — make_subs: то же, но с учётом замен ингредиентов
+func HandleMakeableCallback(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	cq := update.CallbackQuery
+	bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+	if cq.Data == "make_subs" {
+		showMakeable(ctx, bot, cq.Message.Chat.ID, database, cq.From.ID, true)
+	}
+}
+
+// showMakeable — список "можно приготовить / не хватает одного / двух"
+func showMakeable(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, userID int64, withSubstitutes bool) {
+	lang := langOf(userID)
+	cocktails, err := db.GetMakeableCocktails(ctx, database, userID, makeableMaxMissing, makeableLimit, withSubstitutes)
+	if err != nil {
+		log.Println("Ошибка подбора коктейлей из бара:", err)
+		send(bot, chatID, i18n.T(lang, "err.db"))
+		return
+	}
+
+	if len(cocktails) == 0 {
+		send(bot, chatID, i18n.T(lang, "make.nothing"))
+		return
+	}
+
+	localizeMakeable(ctx, database, lang, cocktails)
+	found := make([]db.Cocktail, 0, len(cocktails))
+	for _, c := range cocktails {
+		found = append(found, c.Cocktail)
+	}
+	rememberResults(userID, found)
+
+	msg := tgbotapi.NewMessage(chatID, formatMakeable(cocktails, withSubstitutes, lang))
+	if !withSubstitutes {
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "make.subs_button"), "make_subs"),
+			),
+		)
+	}
+	bot.Send(msg)
+}
+
+// formatMakeable — коктейли, сгруппированные по числу недостающих ингредиентов
+func formatMakeable(cocktails []db.MakeableCocktail, withSubstitutes bool, lang i18n.Lang) string {
+	var ready, one, two []string
+	for _, c := range cocktails {
+		switch len(c.Missing) {
+		case 0:
+			ready = append(ready, i18n.T(lang, "list.item", c.Name))
+		case 1:
+			one = append(one, i18n.T(lang, "make.missing", c.Name, c.Missing[0]))
+		default:
+			two = append(two, i18n.T(lang, "make.missing", c.Name, strings.Join(c.Missing, ", ")))
+		}
+	}
+
+	var parts []string
+	if withSubstitutes {
+		parts = append(parts, i18n.T(lang, "make.with_subs"))
+	}
+	if len(ready) > 0 {
+		parts = append(parts, i18n.T(lang, "make.ready")+"\n"+strings.Join(ready, "\n"))
+	}
+	if len(one) > 0 {
+		parts = append(parts, i18n.T(lang, "make.one")+"\n"+strings.Join(one, "\n"))
+	}
+	if len(two) > 0 {
+		parts = append(parts, i18n.T(lang, "make.two")+"\n"+strings.Join(two, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
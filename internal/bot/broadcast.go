@@ -0,0 +1,240 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/config"
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	broadcastBatch      = 100         // сколько получателей читать из базы за раз
+	broadcastRetryDelay = time.Minute // пауза после временной ошибки отправки
+	broadcastMaxPauses  = 5           // столько пауз подряд — и откладываем до перезапуска
+)
+
+// активные рассылки: не даём запустить одну и ту же дважды
+var (
+	broadcastsMu      sync.Mutex
+	broadcastsRunning = map[int]bool{}
+)
+
+// HandleBroadcast — /broadcast текст: черновик рассылки с предпросмотром и подтверждением
+func HandleBroadcast(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB, cfg *config.Config) {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	lang := langOf(userID)
+	if !cfg.IsAdmin(userID) {
+		send(bot, chatID, i18n.T(lang, "admin.only"))
+		return
+	}
+
+	text := strings.TrimSpace(update.Message.CommandArguments())
+	if text == "" {
+		send(bot, chatID, i18n.T(lang, "broadcast.usage"))
+		return
+	}
+
+	id, err := db.CreateBroadcast(ctx, database, userID, chatID, text)
+	if err != nil {
+		log.Println("Ошибка создания рассылки:", err)
+		send(bot, chatID, i18n.T(lang, "err.db"))
+		return
+	}
+	audit(ctx, database, userID, "broadcast", fmt.Sprintf("#%d %s", id, text))
+
+	recipients, err := db.CountActiveUsers(ctx, database)
+	if err != nil {
+		log.Println("Ошибка подсчёта пользователей:", err)
+	}
+
+	// предпросмотр — ровно то, что получат пользователи
+	send(bot, chatID, text)
+	msg := tgbotapi.NewMessage(chatID, i18n.N(lang, "broadcast.preview", recipients))
+	msg.ReplyMarkup = BroadcastKeyboard(id, lang)
+	bot.Send(msg)
+}
+
+// HandleBroadcastCallback — bcast_send_<id> / bcast_cancel_<id>
+func HandleBroadcastCallback(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB, cfg *config.Config) {
+	cq := update.CallbackQuery
+	bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+	lang := langOf(cq.From.ID)
+	if !cfg.IsAdmin(cq.From.ID) {
+		send(bot, cq.Message.Chat.ID, i18n.T(lang, "admin.only"))
+		return
+	}
+
+	action, rawID, _ := strings.Cut(strings.TrimPrefix(cq.Data, "bcast_"), "_")
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		return
+	}
+
+	var reply string
+	switch action {
+	case "send":
+		if _, err = db.StartBroadcast(ctx, database, id); err == nil {
+			audit(ctx, database, cq.From.ID, "broadcast_send", fmt.Sprintf("#%d", id))
+			reply = i18n.T(lang, "broadcast.started", id)
+			go resumeBroadcast(ctx, bot, database, id)
+		}
+	case "cancel":
+		if err = db.CancelBroadcast(ctx, database, id); err == nil {
+			audit(ctx, database, cq.From.ID, "broadcast_cancel", fmt.Sprintf("#%d", id))
+			reply = i18n.T(lang, "broadcast.cancelled")
+		}
+	default:
+		return
+	}
+	switch {
+	case errors.Is(err, db.ErrBroadcastNotDraft):
+		reply = i18n.T(lang, "broadcast.not_draft")
+	case err != nil:
+		log.Println("Ошибка рассылки:", err)
+		reply = i18n.T(lang, "err.db")
+	}
+
+	// убираем кнопки, чтобы рассылку нельзя было подтвердить повторно
+	bot.Send(tgbotapi.NewEditMessageText(cq.Message.Chat.ID, cq.Message.MessageID, reply))
+}
+
+// ResumeBroadcasts — продолжает рассылки, прерванные остановкой бота
+func ResumeBroadcasts(ctx context.Context, bot *tgbotapi.BotAPI, database *sql.DB) {
+	broadcasts, err := db.GetSendingBroadcasts(ctx, database)
+	if err != nil {
+		log.Println("Ошибка загрузки незавершённых рассылок:", err)
+		return
+	}
+	for _, b := range broadcasts {
+		log.Printf("📣 Продолжаем рассылку #%d", b.ID)
+		go resumeBroadcast(ctx, bot, database, b.ID)
+	}
+}
+
+// итог отправки рассылки одному получателю
+type sendOutcome int
+
+const (
+	recipientDelivered sendOutcome = iota // доставлено
+	recipientFailed                       // не доставлено окончательно
+	recipientRetry                        // временная ошибка: получатель остаётся в очереди
+)
+
+// recipientOutcome — что делать с получателем после bot.Send
+func recipientOutcome(err error) sendOutcome {
+	switch {
+	case err == nil:
+		return recipientDelivered
+	case isPermanentSendError(err):
+		// окончательные 400/403 — пользователь удалён, заблокировал бота и т. п.
+		return recipientFailed
+	default:
+		// сеть, 5xx, исчерпанные повторы 429, остановка бота
+		return recipientRetry
+	}
+}
+
+// nextPause — счётчик пауз подряд после очередной пачки; giveUp — пора отложить
+// рассылку до перезапуска. Успешная пачка сбрасывает счётчик
+func nextPause(pauses int, batchErr error) (next int, giveUp bool) {
+	if batchErr == nil {
+		return 0, false
+	}
+	return pauses + 1, pauses+1 > broadcastMaxPauses
+}
+
+// resumeBroadcast — отправляет рассылку оставшимся получателям и присылает отчёт.
+// Темп задаёт Sender: каждый bot.Send ждёт своей очереди, а заблокировавшие бота
+// пользователи помечаются им же. Статус сохраняется после каждой отправки,
+// поэтому после перезапуска рассылка продолжается с того же места.
+// Недоставленным получатель считается только при окончательной ошибке;
+// при временной рассылка делает паузу и повторяет отправку ему же
+func resumeBroadcast(ctx context.Context, bot *tgbotapi.BotAPI, database *sql.DB, id int) {
+	broadcastsMu.Lock()
+	if broadcastsRunning[id] {
+		broadcastsMu.Unlock()
+		return
+	}
+	broadcastsRunning[id] = true
+	broadcastsMu.Unlock()
+	defer func() {
+		broadcastsMu.Lock()
+		delete(broadcastsRunning, id)
+		broadcastsMu.Unlock()
+	}()
+
+	b, err := db.GetBroadcast(ctx, database, id)
+	if err != nil {
+		log.Printf("❌ Ошибка загрузки рассылки #%d: %v", id, err)
+		return
+	}
+
+	pauses, giveUp := 0, false
+	for {
+		recipients, err := db.GetPendingRecipients(ctx, database, id, broadcastBatch)
+		if err != nil {
+			// при остановке бота ctx отменяется — рассылка продолжится после запуска
+			log.Printf("⚠️ Рассылка #%d приостановлена: %v", id, err)
+			return
+		}
+		if len(recipients) == 0 {
+			break
+		}
+
+		sent := 0
+		var sendErr error
+		for _, userID := range recipients {
+			if ctx.Err() != nil {
+				log.Printf("⚠️ Рассылка #%d приостановлена: %v", id, ctx.Err())
+				return
+			}
+			_, sendErr = bot.Send(tgbotapi.NewMessage(userID, b.Text))
+			outcome := recipientOutcome(sendErr)
+			if outcome == recipientRetry {
+				break
+			}
+			if err := db.MarkRecipient(ctx, database, id, userID, outcome == recipientDelivered); err != nil {
+				log.Printf("⚠️ Рассылка #%d: не удалось сохранить статус для %d: %v", id, userID, err)
+			}
+			sendErr = nil
+			sent++
+		}
+		log.Printf("📣 Рассылка #%d: обработано ещё %d", id, sent)
+
+		pauses, giveUp = nextPause(pauses, sendErr)
+		if sendErr == nil {
+			continue
+		}
+		if giveUp {
+			log.Printf("⚠️ Рассылка #%d отложена до перезапуска: %v", id, sendErr)
+			return
+		}
+		log.Printf("⚠️ Рассылка #%d: временная ошибка, повтор через %s: %v", id, broadcastRetryDelay, sendErr)
+		select {
+		case <-ctx.Done():
+			log.Printf("⚠️ Рассылка #%d приостановлена: %v", id, ctx.Err())
+			return
+		case <-time.After(broadcastRetryDelay):
+		}
+	}
+
+	b, err = db.FinishBroadcast(ctx, database, id)
+	if err != nil {
+		log.Printf("❌ Ошибка завершения рассылки #%d: %v", id, err)
+		return
+	}
+	log.Printf("✅ Рассылка #%d завершена: доставлено %d, не доставлено %d", id, b.Delivered, b.Failed)
+	lang := loadLang(ctx, database, b.AdminID)
+	send(bot, b.ChatID, i18n.T(lang, "broadcast.report", id, b.Delivered, b.Failed))
+}
@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestRecipientOutcome(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want sendOutcome
+	}{
+		{"доставлено", nil, recipientDelivered},
+		{"заблокировал бота", &tgbotapi.Error{Code: 403, Message: "Forbidden: bot was blocked by the user"}, recipientFailed},
+		{"чат не найден", &tgbotapi.Error{Code: 400, Message: "Bad Request: chat not found"}, recipientFailed},
+		{"обёрнутая 403", fmt.Errorf("send: %w", &tgbotapi.Error{Code: 403}), recipientFailed},
+		{"429", &tgbotapi.Error{Code: 429, Message: "Too Many Requests"}, recipientRetry},
+		{"502", &tgbotapi.Error{Code: 502, Message: "Bad Gateway"}, recipientRetry},
+		{"сеть", errors.New("dial tcp: i/o timeout"), recipientRetry},
+		{"остановка", errSenderStopped, recipientRetry},
+	}
+	for _, tt := range tests {
+		if got := recipientOutcome(tt.err); got != tt.want {
+			t.Errorf("recipientOutcome(%s) = %d; want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNextPause(t *testing.T) {
+	timeout := errors.New("dial tcp: i/o timeout")
+	tests := []struct {
+		name       string
+		pauses     int
+		err        error
+		wantPauses int
+		wantGiveUp bool
+	}{
+		{"успешная пачка", 0, nil, 0, false},
+		{"успех сбрасывает счётчик", 3, nil, 0, false},
+		{"первая пауза", 0, timeout, 1, false},
+		{"последняя допустимая пауза", broadcastMaxPauses - 1, timeout, broadcastMaxPauses, false},
+		{"пауз слишком много", broadcastMaxPauses, timeout, broadcastMaxPauses + 1, true},
+	}
+	for _, tt := range tests {
+		pauses, giveUp := nextPause(tt.pauses, tt.err)
+		if pauses != tt.wantPauses || giveUp != tt.wantGiveUp {
+			t.Errorf("nextPause(%s) = %d, %v; want %d, %v", tt.name, pauses, giveUp, tt.wantPauses, tt.wantGiveUp)
+		}
+	}
+}
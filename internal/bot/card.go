@@ -0,0 +1,231 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	captionLimit        = 1024 // ограничение Telegram на подпись к фото
+	cardSubstituteLimit = 2    // сколько замен подсказывать на ингредиент
+)
+
+// SendCocktailCard — карточка коктейля: фото, ингредиенты, способ приготовления и кнопки.
+// Оценка и заметка пользователя личные — они показываются только в личном чате с ним.
+// extra — дополнительные ряды кнопок под стандартными.
+// Возвращает ошибку, если карточку доставить не удалось
+func SendCocktailCard(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, cocktailID int, userID int64, extra ...[]tgbotapi.InlineKeyboardButton) error {
+	return sendCard(ctx, bot, chatID, database, cocktailID, userID, "", extra...)
+}
+
+// sendCard — SendCocktailCard с заголовком над карточкой ("" — без него).
+// Заголовок входит в ту же подпись, поэтому карточка приходит одним сообщением
+func sendCard(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, cocktailID int, userID int64, header string, extra ...[]tgbotapi.InlineKeyboardButton) error {
+	lang := langOf(userID)
+	c, err := db.GetCocktail(ctx, database, cocktailID)
+	if err == sql.ErrNoRows {
+		send(bot, chatID, i18n.T(lang, "card.not_found"))
+		return err
+	}
+	if err != nil {
+		log.Println("Ошибка получения коктейля:", err)
+		send(bot, chatID, i18n.T(lang, "err.db"))
+		return err
+	}
+
+	var rating int
+	var note string
+	if chatID == userID {
+		rating, note, err = db.GetPersonal(ctx, database, userID, c.ID)
+		if err != nil {
+			log.Println("Ошибка получения оценки:", err)
+		}
+	}
+
+	localized := []db.Cocktail{c}
+	localize(ctx, database, lang, localized)
+	c = localized[0]
+
+	subs := cardSubstitutes(ctx, database, c, userID)
+	text := formatCocktailCard(c, subs, rating, note, lang)
+	if header != "" {
+		text = header + "\n\n" + text
+	}
+	keyboard := CocktailCardKeyboard(c.ID, lang)
+	if chatID != userID {
+		// в группе избранное, оценки и заметки не работают — оставляем похожие
+		keyboard = GroupCardKeyboard(c.ID, lang)
+	}
+	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, extra...)
+
+	if c.ImageURL != "" && len([]rune(text)) <= captionLimit {
+		photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileURL(c.ImageURL))
+		photo.Caption = text
+		photo.ReplyMarkup = keyboard
+		if _, err := bot.Send(photo); err == nil {
+			return nil
+		}
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = keyboard
+	_, err = bot.Send(msg)
+	return err
+}
+
+// cardSubstitutes — замены для ингредиентов, которых нет в баре пользователя.
+// Замены, которые в баре есть, идут первыми. Пустой бар — подсказок нет
+func cardSubstitutes(ctx context.Context, database *sql.DB, c db.Cocktail, userID int64) map[int][]db.Substitute {
+	owned, err := db.GetOwnedGoodIDs(ctx, database, userID)
+	if err != nil {
+		log.Println("Ошибка получения бара:", err)
+		return nil
+	}
+	if len(owned) == 0 {
+		return nil
+	}
+
+	var missing []int
+	for _, ing := range c.Ingredients {
+		if !owned[ing.GoodID] {
+			missing = append(missing, ing.GoodID)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	subs, err := db.GetSubstitutes(ctx, database, missing, cardSubstituteLimit)
+	if err != nil {
+		log.Println("Ошибка получения замен:", err)
+		return nil
+	}
+	for id, list := range subs {
+		sort.SliceStable(list, func(i, j int) bool { return owned[list[i].ID] && !owned[list[j].ID] })
+		for i := range list {
+			// в карточке отмечаем, что замена уже есть дома
+			if owned[list[i].ID] {
+				list[i].Name = "✅ " + list[i].Name
+			}
+		}
+		subs[id] = list
+	}
+	return subs
+}
+
+// formatCocktailCard — текст карточки без разметки (в названиях бывают * и _)
+func formatCocktailCard(c db.Cocktail, subs map[int][]db.Substitute, rating int, note string, lang i18n.Lang) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "🍸 %s\n", c.Name)
+	if rating > 0 {
+		b.WriteString(i18n.T(lang, "card.rating", formatStars(rating)) + "\n")
+	}
+	if note != "" {
+		fmt.Fprintf(&b, "📝 %s\n", note)
+	}
+
+	if len(c.Ingredients) > 0 {
+		b.WriteString("\n")
+		for _, ing := range c.Ingredients {
+			amount := strings.TrimSpace(ing.Amount + " " + ing.Unit)
+			if amount != "" {
+				fmt.Fprintf(&b, "• %s — %s\n", ing.Good.Name, amount)
+			} else {
+				fmt.Fprintf(&b, "• %s\n", ing.Good.Name)
+			}
+			if list := subs[ing.GoodID]; len(list) > 0 {
+				names := make([]string, 0, len(list))
+				for _, sub := range list {
+					names = append(names, sub.Name)
+				}
+				b.WriteString(i18n.T(lang, "card.substitute", strings.Join(names, ", ")) + "\n")
+			}
+		}
+	}
+
+	if c.Instructions != "" {
+		fmt.Fprintf(&b, "\n%s\n", c.Instructions)
+	}
+	if c.URL != "" {
+		fmt.Fprintf(&b, "\n%s", c.URL)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+const similarLimit = 8 // сколько похожих коктейлей показывать
+
+// showSimilar — коктейли с наибольшим пересечением ингредиентов
+func showSimilar(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, cocktailID int, userID int64) {
+	lang := langOf(userID)
+	matches, err := db.GetSimilarCocktails(ctx, database, userID, cocktailID, similarLimit)
+	if err != nil {
+		log.Println("Ошибка поиска похожих коктейлей:", err)
+		send(bot, chatID, i18n.T(lang, "err.db"))
+		return
+	}
+	if len(matches) == 0 {
+		send(bot, chatID, i18n.T(lang, "similar.none"))
+		return
+	}
+
+	list := make([]db.Cocktail, 0, len(matches))
+	for _, m := range matches {
+		list = append(list, m.Cocktail)
+	}
+	localize(ctx, database, lang, list)
+
+	lines := []string{i18n.T(lang, "similar.title")}
+	for i, m := range matches {
+		lines = append(lines, i18n.T(lang, "similar.item", list[i].Name, int(m.Score*100+0.5)))
+	}
+	rememberResults(userID, list)
+
+	msg := tgbotapi.NewMessage(chatID, strings.Join(lines, "\n"))
+	msg.ReplyMarkup = CocktailListKeyboard(list)
+	bot.Send(msg)
+}
+
+// localize — названия, способ приготовления и ингредиенты на языке пользователя,
+// если для них есть перевод
+func localize(ctx context.Context, database *sql.DB, lang i18n.Lang, cocktails []db.Cocktail) {
+	if err := db.LocalizeCocktails(ctx, database, string(lang), cocktails); err != nil {
+		log.Println("Ошибка получения переводов:", err)
+	}
+}
+
+// localizeMakeable — переводы названий коктейлей и недостающих ингредиентов для /make
+func localizeMakeable(ctx context.Context, database *sql.DB, lang i18n.Lang, cocktails []db.MakeableCocktail) {
+	list := make([]db.Cocktail, 0, len(cocktails))
+	var missing []string
+	for _, c := range cocktails {
+		list = append(list, c.Cocktail)
+		missing = append(missing, c.Missing...)
+	}
+	localize(ctx, database, lang, list)
+
+	names, err := db.TranslateGoodNames(ctx, database, string(lang), missing)
+	if err != nil {
+		log.Println("Ошибка получения переводов:", err)
+	}
+	for i := range cocktails {
+		cocktails[i].Cocktail = list[i]
+		for j, name := range cocktails[i].Missing {
+			if t, ok := names[name]; ok {
+				cocktails[i].Missing[j] = t
+			}
+		}
+	}
+}
+
+// formatStars — оценка звёздами: ★★★★☆
+func formatStars(rating int) string {
+	return strings.Repeat("★", rating) + strings.Repeat("☆", 5-rating)
+}
@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+)
+
+// negroni — карточка без фото: джин, кампари и вермут без количества
+var negroni = db.Cocktail{
+	Name:         "Негрони",
+	Instructions: "Перемешай в стакане",
+	URL:          "https://ru.inshaker.com/cocktails/1",
+	Ingredients: []db.CocktailIngredient{
+		{GoodID: 1, Good: db.Good{ID: 1, Name: "Джин"}, Amount: "30", Unit: "мл"},
+		{GoodID: 2, Good: db.Good{ID: 2, Name: "Кампари"}, Amount: "30", Unit: "мл"},
+		{GoodID: 3, Good: db.Good{ID: 3, Name: "Красный вермут"}},
+	},
+}
+
+func TestFormatCocktailCardSubstitutes(t *testing.T) {
+	subs := map[int][]db.Substitute{
+		2: {{Good: db.Good{Name: "Апероль"}, Curated: true}, {Good: db.Good{Name: "Сайнар"}}},
+	}
+	tests := []struct {
+		name string
+		subs map[int][]db.Substitute
+		lang i18n.Lang
+		want string
+	}{
+		{"без замен", nil, i18n.RU,
+			"🍸 Негрони\n\n• Джин — 30 мл\n• Кампари — 30 мл\n• Красный вермут\n\nПеремешай в стакане\n\nhttps://ru.inshaker.com/cocktails/1"},
+		{"замены под ингредиентом", subs, i18n.RU,
+			"🍸 Негрони\n\n• Джин — 30 мл\n• Кампари — 30 мл\n   ↔ замена: Апероль, Сайнар\n• Красный вермут\n\nПеремешай в стакане\n\nhttps://ru.inshaker.com/cocktails/1"},
+		{"замены по-английски", subs, i18n.EN,
+			"🍸 Негрони\n\n• Джин — 30 мл\n• Кампари — 30 мл\n   ↔ substitute: Апероль, Сайнар\n• Красный вермут\n\nПеремешай в стакане\n\nhttps://ru.inshaker.com/cocktails/1"},
+	}
+	for _, tt := range tests {
+		if got := formatCocktailCard(negroni, tt.subs, 0, "", tt.lang); got != tt.want {
+			t.Errorf("formatCocktailCard(%s) = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFormatStars(t *testing.T) {
+	tests := []struct {
+		rating int
+		want   string
+	}{
+		{1, "★☆☆☆☆"},
+		{3, "★★★☆☆"},
+		{5, "★★★★★"},
+	}
+	for _, tt := range tests {
+		if got := formatStars(tt.rating); got != tt.want {
+			t.Errorf("formatStars(%d) = %q; want %q", tt.rating, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCocktailCardRating(t *testing.T) {
+	c := db.Cocktail{Name: "Негрони"}
+	tests := []struct {
+		name   string
+		rating int
+		note   string
+		lang   i18n.Lang
+		want   string
+	}{
+		{"без оценки и заметки", 0, "", i18n.RU, "🍸 Негрони"},
+		{"оценка", 4, "", i18n.RU, "🍸 Негрони\nТвоя оценка: ★★★★☆"},
+		{"заметка", 0, "меньше кампари", i18n.RU, "🍸 Негрони\n📝 меньше кампари"},
+		{"оценка и заметка", 5, "идеально", i18n.EN, "🍸 Негрони\nYour rating: ★★★★★\n📝 идеально"},
+	}
+	for _, tt := range tests {
+		if got := formatCocktailCard(c, nil, tt.rating, tt.note, tt.lang); got != tt.want {
+			t.Errorf("formatCocktailCard(%s) = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	defaultTimezone = "Europe/Moscow"
+	dailyTick       = time.Minute // как часто планировщик проверяет подписки
+)
+
+// HandleDaily — /daily 09:30 [Europe/Moscow] — подписка, /daily off — отписка, /daily — статус
+func HandleDaily(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	lang := langOf(userID)
+	args := strings.Fields(update.Message.CommandArguments())
+
+	if len(args) == 0 {
+		sub, ok, err := db.GetDailySubscription(ctx, database, userID)
+		if err != nil {
+			log.Println("Ошибка получения подписки:", err)
+			send(bot, chatID, i18n.T(lang, "err.db"))
+			return
+		}
+		if !ok {
+			send(bot, chatID, i18n.T(lang, "daily.usage"))
+			return
+		}
+		send(bot, chatID, i18n.T(lang, "daily.status", formatMinute(sub.SendMinute), sub.Timezone))
+		return
+	}
+
+	if arg := strings.ToLower(args[0]); arg == "off" || arg == "stop" || arg == "выкл" {
+		if err := db.UnsubscribeDaily(ctx, database, userID); err != nil {
+			log.Println("Ошибка отписки:", err)
+			send(bot, chatID, i18n.T(lang, "daily.unsub_failed"))
+			return
+		}
+		send(bot, chatID, i18n.T(lang, "daily.unsubscribed"))
+		return
+	}
+
+	at, err := time.Parse("15:04", args[0])
+	if err != nil {
+		send(bot, chatID, i18n.T(lang, "daily.bad_time"))
+		return
+	}
+
+	tz := defaultTimezone
+	if len(args) > 1 {
+		tz = args[1]
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		send(bot, chatID, i18n.T(lang, "daily.bad_tz"))
+		return
+	}
+
+	sub := db.DailySubscription{
+		UserID:     userID,
+		ChatID:     chatID,
+		SendMinute: at.Hour()*60 + at.Minute(),
+		Timezone:   tz,
+	}
+	if err := db.SubscribeDaily(ctx, database, sub); err != nil {
+		log.Println("Ошибка подписки:", err)
+		send(bot, chatID, i18n.T(lang, "daily.sub_failed"))
+		return
+	}
+	send(bot, chatID, i18n.T(lang, "daily.subscribed", formatMinute(sub.SendMinute), tz))
+}
+
+// RunDailyScheduler — раз в минуту рассылает коктейль дня тем, у кого подошло время.
+// Дата последней отправки хранится в базе, поэтому после перезапуска ничего
+// не дублируется, а пропущенное за сегодня досылается
+func RunDailyScheduler(ctx context.Context, bot *tgbotapi.BotAPI, database *sql.DB) {
+	ticker := time.NewTicker(dailyTick)
+	defer ticker.Stop()
+
+	for {
+		sendDueDaily(ctx, bot, database, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDueDaily — одна проверка подписок
+func sendDueDaily(ctx context.Context, bot *tgbotapi.BotAPI, database *sql.DB, now time.Time) {
+	subs, err := db.GetDailySubscriptions(ctx, database)
+	if err != nil {
+		log.Println("Ошибка получения подписок:", err)
+		return
+	}
+
+	for _, sub := range subs {
+		today, due := dailyDue(sub, now)
+		if !due {
+			continue
+		}
+
+		c, err := db.PickDailyCocktail(ctx, database, sub.UserID)
+		if err != nil {
+			log.Printf("Ошибка выбора коктейля дня для %d: %v", sub.UserID, err)
+			continue
+		}
+
+		// планировщик работает вне апдейтов — язык берём из базы.
+		// Заголовок — часть карточки: при повторе не будет лишних заголовков
+		lang := loadLang(ctx, database, sub.UserID)
+		err = sendCard(ctx, bot, sub.ChatID, database, c.ID, sub.UserID, i18n.T(lang, "daily.header"))
+		// отметку ставим только после доставки: временные сбои повторятся на следующем
+		// тике, а отказ Telegram (4xx) повторять бесполезно — пропускаем день
+		if err != nil && !isPermanentSendError(err) {
+			log.Printf("⚠️ Коктейль дня для %d не отправлен, повторим позже: %v", sub.UserID, err)
+			continue
+		}
+		if err != nil {
+			log.Printf("❌ Коктейль дня для %d не отправлен: %v", sub.UserID, err)
+		}
+
+		if err := db.MarkDailySent(ctx, database, sub.UserID, c.ID, today); err != nil {
+			log.Printf("Ошибка сохранения отправки коктейля дня для %d: %v", sub.UserID, err)
+		}
+	}
+}
+
+// dailyDue — пора ли отправлять: местное время наступило, а сегодня ещё не отправляли
+func dailyDue(sub db.DailySubscription, now time.Time) (time.Time, bool) {
+	loc, err := time.LoadLocation(sub.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	if !sub.LastSentOn.IsZero() && sub.LastSentOn.Format("2006-01-02") >= today.Format("2006-01-02") {
+		return today, false
+	}
+	return today, local.Hour()*60+local.Minute() >= sub.SendMinute
+}
+
+// formatMinute — минуты от полуночи в виде 09:30
+func formatMinute(m int) string {
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
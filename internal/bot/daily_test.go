@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // часовые пояса не зависят от системы, как и в cmd/bot
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+)
+
+func TestDailyDue(t *testing.T) {
+	// 06:30 UTC — 09:30 в Москве (UTC+3)
+	now := time.Date(2024, 3, 10, 6, 30, 0, 0, time.UTC)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		sub     db.DailySubscription
+		now     time.Time
+		wantDay time.Time
+		wantDue bool
+	}{
+		{
+			name:    "время наступило, ещё не отправляли",
+			sub:     db.DailySubscription{SendMinute: 9 * 60, Timezone: "Europe/Moscow"},
+			now:     now,
+			wantDay: day(2024, 3, 10),
+			wantDue: true,
+		},
+		{
+			name:    "ровно в назначенную минуту",
+			sub:     db.DailySubscription{SendMinute: 9*60 + 30, Timezone: "Europe/Moscow"},
+			now:     now,
+			wantDay: day(2024, 3, 10),
+			wantDue: true,
+		},
+		{
+			name:    "время ещё не наступило",
+			sub:     db.DailySubscription{SendMinute: 10 * 60, Timezone: "Europe/Moscow"},
+			now:     now,
+			wantDay: day(2024, 3, 10),
+			wantDue: false,
+		},
+		{
+			name:    "сегодня уже отправляли",
+			sub:     db.DailySubscription{SendMinute: 9 * 60, Timezone: "Europe/Moscow", LastSentOn: day(2024, 3, 10)},
+			now:     now,
+			wantDay: day(2024, 3, 10),
+			wantDue: false,
+		},
+		{
+			name:    "вчерашняя отправка не мешает",
+			sub:     db.DailySubscription{SendMinute: 9 * 60, Timezone: "Europe/Moscow", LastSentOn: day(2024, 3, 9)},
+			now:     now,
+			wantDay: day(2024, 3, 10),
+			wantDue: true,
+		},
+		{
+			name:    "местная дата уже следующая",
+			sub:     db.DailySubscription{SendMinute: 8 * 60, Timezone: "Asia/Tokyo", LastSentOn: day(2024, 3, 10)},
+			now:     time.Date(2024, 3, 10, 23, 30, 0, 0, time.UTC),
+			wantDay: day(2024, 3, 11),
+			wantDue: true,
+		},
+		{
+			name:    "неизвестный пояс — UTC",
+			sub:     db.DailySubscription{SendMinute: 7 * 60, Timezone: "Mars/Olympus"},
+			now:     now,
+			wantDay: day(2024, 3, 10),
+			wantDue: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDay, gotDue := dailyDue(tt.sub, tt.now)
+			if !gotDay.Equal(tt.wantDay) || gotDue != tt.wantDue {
+				t.Errorf("dailyDue() = %s, %v; want %s, %v", gotDay.Format("2006-01-02"), gotDue, tt.wantDay.Format("2006-01-02"), tt.wantDue)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/config"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// groupCommands — команды, которые работают в группах. Остальные завязаны на
+// личное состояние пользователя и в группе только подсказывают написать в личку
+var groupCommands = map[string]bool{
+	"start": true, "find": true, "search": true, "bring": true, "party": true,
+}
+
+// groupCallbacks — кнопки, которые можно нажимать в группе: они не зависят
+// от того, кто отправил сообщение с кнопкой
+var groupCallbacks = []string{"party_", "cocktail_", "similar_"}
+
+// isGroupCallback — кнопка допустима в групповом чате
+func isGroupCallback(data string) bool {
+	for _, prefix := range groupCallbacks {
+		if strings.HasPrefix(data, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Dispatch — передаёт апдейт нужному обработчику. Общий для polling и webhook
+func Dispatch(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB, cfg *config.Config) {
+	TrackUser(ctx, database, update)
+
+	if update.Message != nil {
+		switch {
+		case update.Message.IsCommand() && isForOtherBot(bot, update.Message):
+			// команда другому боту в той же группе
+		case update.Message.IsCommand() && isGroup(update.Message.Chat) && !groupCommands[update.Message.Command()]:
+			HandlePrivateOnly(bot, update)
+		case update.Message.IsCommand():
+			switch update.Message.Command() {
+			case "start":
+				HandleStart(bot, update)
+			case "shopping":
+				HandleShopping(ctx, bot, update, database)
+			case "bar":
+				HandleBar(ctx, bot, update, database)
+			case "make":
+				HandleMakeable(ctx, bot, update, database)
+			case "find":
+				HandleFind(ctx, bot, update, database)
+			case "search":
+				HandleSearch(ctx, bot, update, database)
+			case "random":
+				HandleRandom(ctx, bot, update, database)
+			case "favorites":
+				HandleFavorites(ctx, bot, update, database)
+			case "recommend":
+				HandleRecommend(ctx, bot, update, database)
+			case "daily":
+				HandleDaily(ctx, bot, update, database)
+			case "lang":
+				HandleLang(ctx, bot, update, database)
+			case "admin":
+				HandleAdmin(ctx, bot, update, database, cfg)
+			case "alias":
+				HandleAlias(ctx, bot, update, database, cfg)
+			case "broadcast":
+				HandleBroadcast(ctx, bot, update, database, cfg)
+			case "bring":
+				HandleBring(ctx, bot, update, database)
+			case "party":
+				HandleParty(ctx, bot, update, database)
+			}
+		case isGroup(update.Message.Chat):
+			HandleGroupMessage(ctx, bot, update, database)
+		default:
+			HandleTextInput(ctx, bot, update, database, cfg)
+		}
+	} else if update.InlineQuery != nil {
+		HandleInlineQuery(ctx, bot, update, database)
+	} else if update.CallbackQuery != nil {
+		cq := update.CallbackQuery
+		if cq.Message == nil {
+			// кнопки под сообщениями из inline-режима — только ссылки
+			return
+		}
+		if isGroup(cq.Message.Chat) && !isGroupCallback(cq.Data) {
+			bot.Request(tgbotapi.NewCallbackWithAlert(cq.ID, i18n.T(langOf(cq.From.ID), "group.private_button")))
+			return
+		}
+		switch {
+		case strings.HasPrefix(update.CallbackQuery.Data, "shop_"):
+			HandleShoppingCallback(ctx, bot, update, database)
+		case strings.HasPrefix(update.CallbackQuery.Data, "bar_"):
+			HandleBarCallback(ctx, bot, update, database)
+		case strings.HasPrefix(update.CallbackQuery.Data, "make_"):
+			HandleMakeableCallback(ctx, bot, update, database)
+		case strings.HasPrefix(update.CallbackQuery.Data, "random_"):
+			HandleRandomCallback(ctx, bot, update, database)
+		case strings.HasPrefix(update.CallbackQuery.Data, "favs_"):
+			HandleFavoritesCallback(ctx, bot, update, database)
+		case strings.HasPrefix(update.CallbackQuery.Data, "lang_"):
+			HandleLangCallback(ctx, bot, update, database)
+		case strings.HasPrefix(update.CallbackQuery.Data, "admin_"):
+			HandleAdminCallback(ctx, bot, update, database, cfg)
+		case strings.HasPrefix(update.CallbackQuery.Data, "party_"):
+			HandlePartyCallback(ctx, bot, update, database)
+		case strings.HasPrefix(update.CallbackQuery.Data, "bcast_"):
+			HandleBroadcastCallback(ctx, bot, update, database, cfg)
+		case strings.HasPrefix(update.CallbackQuery.Data, "good_"):
+			HandleIngredientConfirm(ctx, bot, update, database)
+		default:
+			HandleIngredientConfirm(ctx, bot, update, database)
+			HandleCallback(ctx, bot, update, database)
+		}
+	}
+}
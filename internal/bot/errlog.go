@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"strings"
+	"sync"
+)
+
+// RecentErrors — последние ошибки из лога для /admin errors.
+// Подключается в main через log.SetOutput(io.MultiWriter(os.Stderr, bot.RecentErrors))
+var RecentErrors = NewErrorLog(20)
+
+// ErrorLog — кольцевой буфер строк лога с ошибками и предупреждениями
+type ErrorLog struct {
+	mu    sync.Mutex
+	lines []string
+	size  int
+}
+
+// NewErrorLog — буфер на size строк
+func NewErrorLog(size int) *ErrorLog {
+	return &ErrorLog{size: size}
+}
+
+// Write — io.Writer для log: log пишет каждую запись одним вызовом
+func (e *ErrorLog) Write(p []byte) (int, error) {
+	line := strings.TrimSpace(string(p))
+	if !isErrorLine(line) {
+		return len(p), nil
+	}
+	if r := []rune(line); len(r) > 300 {
+		line = string(r[:300]) + "…"
+	}
+
+	e.mu.Lock()
+	e.lines = append(e.lines, line)
+	if len(e.lines) > e.size {
+		e.lines = e.lines[len(e.lines)-e.size:]
+	}
+	e.mu.Unlock()
+	return len(p), nil
+}
+
+// Lines — сохранённые строки, новые первыми
+func (e *ErrorLog) Lines() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	result := make([]string, 0, len(e.lines))
+	for i := len(e.lines) - 1; i >= 0; i-- {
+		result = append(result, e.lines[i])
+	}
+	return result
+}
+
+// isErrorLine — в проекте ошибки логируются с ❌/⚠️ или словом "Ошибка"
+func isErrorLine(line string) bool {
+	return strings.Contains(line, "❌") || strings.Contains(line, "⚠️") || strings.Contains(line, "Ошибка")
+}
@@ -0,0 +1,106 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// HandleFavorites — /favorites [оценка]: избранное, по дате или по личной оценке
+func HandleFavorites(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	arg := strings.ToLower(strings.TrimSpace(update.Message.CommandArguments()))
+	byRating := strings.HasPrefix(arg, "оцен") || arg == "rating"
+	showFavorites(ctx, bot, update.Message.Chat.ID, 0, database, update.Message.From.ID, byRating, 0)
+}
+
+// HandleFavoritesCallback — favs_rating / favs_date: пересортировать список,
+// favs_rating_<страница> / favs_date_<страница>: листать его
+func HandleFavoritesCallback(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	cq := update.CallbackQuery
+	bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+	order, rawPage, _ := strings.Cut(strings.TrimPrefix(cq.Data, "favs_"), "_")
+	page, _ := strconv.Atoi(rawPage)
+	showFavorites(ctx, bot, cq.Message.Chat.ID, cq.Message.MessageID, database, cq.From.ID, order == "rating", page)
+}
+
+// showFavorites — страница списка избранного с оценками и заметками. messageID != 0 —
+// редактируем уже отправленный список вместо нового сообщения
+func showFavorites(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, messageID int, database *sql.DB, userID int64, byRating bool, page int) {
+	lang := langOf(userID)
+	favorites, err := db.GetRatedFavorites(ctx, database, userID, byRating)
+	if err != nil {
+		log.Println("Ошибка получения избранного:", err)
+		send(bot, chatID, i18n.T(lang, "err.db"))
+		return
+	}
+	if len(favorites) == 0 {
+		send(bot, chatID, i18n.T(lang, "fav.empty"))
+		return
+	}
+
+	list := make([]db.Cocktail, 0, len(favorites))
+	for _, f := range favorites {
+		list = append(list, f.Cocktail)
+	}
+	localize(ctx, database, lang, list)
+
+	from, to, page, pages := paginate(len(favorites), page)
+	var lines []string
+	for i := from; i < to; i++ {
+		f := favorites[i]
+		line := i18n.T(lang, "list.item", list[i].Name)
+		if f.Rating > 0 {
+			line += " " + formatStars(f.Rating)
+		}
+		if f.Note != "" {
+			line += fmt.Sprintf("\n   📝 %s", f.Note)
+		}
+		lines = append(lines, line)
+	}
+	rememberResults(userID, list)
+
+	// заметки бывают длинными — страница всё равно должна уложиться в сообщение
+	text := joinWithinLimit(i18n.T(lang, "fav.title"), lines, "\n")
+	order := "date"
+	if byRating {
+		order = "rating"
+	}
+	keyboard := CocktailListKeyboard(list[from:to])
+	if nav := PageRow("favs_"+order+"_", page, pages); nav != nil {
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, nav)
+	}
+	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, FavoritesSortKeyboard(byRating, lang).InlineKeyboard...)
+
+	if messageID != 0 {
+		bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, text, keyboard))
+		return
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = keyboard
+	bot.Send(msg)
+}
+
+// saveNote — сохраняет заметку к коктейлю; "-" удаляет её
+func saveNote(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, userID int64, cocktailID int, note string) {
+	lang := langOf(userID)
+	if note == "-" {
+		note = ""
+	}
+	if err := db.SetNote(ctx, database, userID, cocktailID, note); err != nil {
+		log.Println("Ошибка сохранения заметки:", err)
+		send(bot, chatID, i18n.T(lang, "note.save_failed"))
+		return
+	}
+	if note == "" {
+		send(bot, chatID, i18n.T(lang, "note.deleted"))
+		return
+	}
+	send(bot, chatID, i18n.T(lang, "note.saved"))
+}
@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const findLimit = 10 // сколько лучших совпадений показывать в /find
+
+// HandleFind — /find лайм, ром, мята: ранжированный поиск по нескольким ингредиентам
+func HandleFind(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	lang := langOf(userID)
+
+	args := strings.TrimSpace(update.Message.CommandArguments())
+	if args == "" {
+		send(bot, chatID, i18n.T(lang, "find.usage"))
+		return
+	}
+
+	var names, unknown []string
+	for _, name := range strings.Split(args, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		good, ok, err := db.FindGood(ctx, database, name)
+		if err != nil {
+			log.Println("Ошибка поиска ингредиента:", err)
+			send(bot, chatID, i18n.T(lang, "err.db"))
+			return
+		}
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		names = append(names, good.Name)
+	}
+
+	if len(names) == 0 {
+		send(bot, chatID, i18n.T(lang, "find.not_found"))
+		return
+	}
+
+	results, err := db.SearchCocktailsRanked(ctx, database, userID, names, findLimit)
+	if err != nil {
+		log.Println("Ошибка ранжированного поиска:", err)
+		send(bot, chatID, i18n.T(lang, "err.search"))
+		return
+	}
+	if len(results) == 0 {
+		send(bot, chatID, i18n.T(lang, "find.none"))
+		return
+	}
+
+	found := make([]db.Cocktail, 0, len(results))
+	lines := []string{i18n.T(lang, "find.title", strings.Join(names, ", "))}
+	if len(unknown) > 0 {
+		lines = append(lines, i18n.T(lang, "find.unknown", strings.Join(unknown, ", ")))
+	}
+	lines = append(lines, "")
+	for i, r := range results {
+		found = append(found, r.Cocktail)
+		lines = append(lines, fmt.Sprintf("%d. %s\n   %s", i+1, r.Name, explainMatch(r, lang)))
+	}
+	rememberResults(userID, found)
+
+	send(bot, chatID, strings.Join(lines, "\n"))
+}
+
+// explainMatch — пояснение вида "совпало 2 из 3: лайм, ром · 2 из 5 в рецепте"
+func explainMatch(r db.RankedCocktail, lang i18n.Lang) string {
+	return i18n.T(lang, "find.explain",
+		r.Matched, r.Requested, strings.Join(r.MatchedNames, ", "), r.Matched, r.Total)
+}
+
+const searchLimit = 10 // сколько результатов показывать в /search
+
+// HandleSearch — /search лайм -ром: полнотекстовый поиск по рецептам
+func HandleSearch(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	lang := langOf(userID)
+
+	query := strings.TrimSpace(update.Message.CommandArguments())
+	if query == "" {
+		send(bot, chatID, i18n.T(lang, "search.usage"))
+		return
+	}
+
+	matches, err := db.FullTextSearch(ctx, database, userID, query, searchLimit)
+	if err != nil {
+		log.Println("Ошибка полнотекстового поиска:", err)
+		send(bot, chatID, i18n.T(lang, "err.search"))
+		return
+	}
+	if len(matches) == 0 {
+		send(bot, chatID, i18n.T(lang, "search.none"))
+		return
+	}
+
+	found := make([]db.Cocktail, 0, len(matches))
+	for _, m := range matches {
+		found = append(found, m.Cocktail)
+	}
+	rememberResults(userID, found)
+	localize(ctx, database, lang, found)
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "search.title", query))
+	msg.ReplyMarkup = CocktailListKeyboard(found)
+	bot.Send(msg)
+}
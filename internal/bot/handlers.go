@@ -1,116 +1,222 @@
 package bot
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"math"
 	"strconv"
 	"strings"
 
+	"github.com/RZ-ru/Inshakerov_bot/internal/config"
 	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 func HandleStart(bot *tgbotapi.BotAPI, update tgbotapi.Update) {
-	msg := tgbotapi.NewMessage(update.Message.Chat.ID,
-		"👋 Привет! Я помогу подобрать коктейль.\n\nНапиши, какой ингредиент хочешь использовать 🍋🥃")
+	lang := langOf(update.Message.From.ID)
+	if isGroup(update.Message.Chat) {
+		send(bot, update.Message.Chat.ID, i18n.T(lang, "group.start"))
+		return
+	}
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "start"))
 	msg.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
 	bot.Send(msg)
 }
 
-func HandleIngredientInput(bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+// Пороги для решения "это название коктейля или ингредиент"
+const (
+	nameSearchLimit = 5    // сколько коктейлей предлагать при поиске по названию
+	nameMatchMin    = 0.45 // ниже — текст не считаем названием коктейля
+	ambiguityMargin = 0.15 // если оценки ближе — предлагаем оба варианта
+)
+
+// HandleTextInput — свободный текст: название коктейля или ингредиент.
+// Если оба варианта одинаково похожи, спрашиваем пользователя
+func HandleTextInput(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB, cfg *config.Config) {
+	// стикеры, фото и прочие сообщения без текста не ищем
+	text := strings.TrimSpace(strings.ToLower(update.Message.Text))
+	if text == "" {
+		return
+	}
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	lang := langOf(userID)
+
+	// ждём текст заметки после кнопки "📝 Заметка"
+	var noteFor int
+	withSession(userID, func(s *session) {
+		noteFor, s.pendingNote = s.pendingNote, 0
+	})
+	if noteFor != 0 {
+		saveNote(ctx, bot, chatID, database, userID, noteFor, strings.TrimSpace(update.Message.Text))
+		return
+	}
+
+	if isMenuButton(update.Message.Text, "menu.favorites") {
+		showFavorites(ctx, bot, chatID, 0, database, userID, false, 0)
+		return
+	}
+
+	cocktails, err := db.SearchCocktailsByName(ctx, database, text, nameSearchLimit)
+	if err != nil {
+		log.Println("Ошибка поиска коктейля по названию:", err)
+		bot.Send(tgbotapi.NewMessage(chatID, i18n.T(lang, "err.db")))
+		return
+	}
+	goods, err := db.SuggestGoods(ctx, database, text, cfg.SuggestThreshold, 1)
+	if err != nil {
+		log.Println("Ошибка поиска ингредиента:", err)
+		bot.Send(tgbotapi.NewMessage(chatID, i18n.T(lang, "err.db")))
+		return
+	}
+
+	var bestCocktail, bestGood float64
+	if len(cocktails) > 0 {
+		bestCocktail = cocktails[0].Score
+	}
+	if len(goods) > 0 {
+		bestGood = goods[0].Score
+	}
+
+	switch {
+	case bestCocktail >= nameMatchMin && bestGood > 0 && math.Abs(bestCocktail-bestGood) < ambiguityMargin:
+		msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "text.ambiguous"))
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("🍸 "+cocktails[0].Name, fmt.Sprintf("cocktail_%d", cocktails[0].ID)),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("🍋 "+goods[0].Name, fmt.Sprintf("good_%d", goods[0].ID)),
+			),
+		)
+		bot.Send(msg)
+
+	case bestCocktail >= nameMatchMin && bestCocktail > bestGood:
+		showCocktailMatches(ctx, bot, chatID, database, cocktails, userID)
+
+	default:
+		HandleIngredientInput(ctx, bot, update, database, cfg)
+	}
+}
+
+// showCocktailMatches — один уверенный результат сразу карточкой, иначе список кнопок
+func showCocktailMatches(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, matches []db.CocktailMatch, userID int64) {
+	if matches[0].Score >= 1 || len(matches) == 1 {
+		SendCocktailCard(ctx, bot, chatID, database, matches[0].ID, userID)
+		return
+	}
+
+	var list []db.Cocktail
+	for _, m := range matches {
+		if m.Score >= nameMatchMin {
+			list = append(list, m.Cocktail)
+		}
+	}
+	rememberResults(userID, list)
+
+	lang := langOf(userID)
+	localize(ctx, database, lang, list)
+	msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "text.similar_names"))
+	msg.ReplyMarkup = CocktailListKeyboard(list)
+	bot.Send(msg)
+}
+
+func HandleIngredientInput(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB, cfg *config.Config) {
 	text := strings.TrimSpace(strings.ToLower(update.Message.Text))
 	userID := update.Message.From.ID
+	lang := langOf(userID)
 
-	var count int
-	err := database.QueryRow(`SELECT COUNT(*) FROM goods WHERE LOWER(name) = $1`, text).Scan(&count)
+	good, ok, err := db.ResolveGood(ctx, database, text)
 	if err != nil {
 		log.Println("Ошибка при поиске ингредиента:", err)
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "❌ Ошибка при обращении к базе. Попробуй позже."))
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "err.db")))
 		return
 	}
 
-	if count == 0 {
-		rows, err := database.Query(`
-	SELECT name FROM goods 
-	WHERE LOWER(name) % $1 OR LOWER(name) ILIKE '%' || $1 || '%' 
-	LIMIT 3;
-`, text)
+	if !ok {
+		suggestions, err := db.SuggestGoods(ctx, database, text, cfg.SuggestThreshold, cfg.SuggestLimit)
 		if err != nil {
 			log.Println("Ошибка поиска похожих ингредиентов:", err)
-			bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "⚠️ Ошибка поиска похожих ингредиентов."))
+			bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "err.similar_goods")))
 			return
 		}
-		defer rows.Close()
-
-		var suggestions []string
-		for rows.Next() {
-			var name string
-			rows.Scan(&name)
-			suggestions = append(suggestions, name)
-		}
 
 		if len(suggestions) > 0 {
-			similar := suggestions[0]
-			msg := tgbotapi.NewMessage(update.Message.Chat.ID,
-				fmt.Sprintf("🤔 Возможно, вы имели в виду *%s*?", similar))
-			msg.ParseMode = "Markdown"
-			msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData("Да", "confirm_"+similar),
-					tgbotapi.NewInlineKeyboardButtonData("Нет", "reject"),
-				),
-			)
+			msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "ingredient.suggest"))
+			msg.ReplyMarkup = SuggestionsKeyboard(suggestions, lang)
 			bot.Send(msg)
 			return
 		}
 
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "🥲 Такой ингредиент не найден. Попробуй другой."))
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "ingredient.not_found")))
 		return
 	}
 
-	ShowCocktailsForIngredient(bot, update.Message.Chat.ID, database, text, userID)
+	ShowCocktailsForIngredient(ctx, bot, update.Message.Chat.ID, database, strings.ToLower(good.Name), userID)
 }
 
-func HandleIngredientConfirm(bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+func HandleIngredientConfirm(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
 	data := update.CallbackQuery.Data
 	userID := update.CallbackQuery.From.ID
 	chatID := update.CallbackQuery.Message.Chat.ID
+	lang := langOf(userID)
+
+	switch {
+	case strings.HasPrefix(data, "good_"):
+		bot.Request(tgbotapi.NewCallback(update.CallbackQuery.ID, ""))
+		goodID, err := strconv.Atoi(strings.TrimPrefix(data, "good_"))
+		if err != nil {
+			return
+		}
+		good, err := db.GetGood(ctx, database, goodID)
+		if err != nil {
+			log.Println("Ошибка получения ингредиента:", err)
+			send(bot, chatID, i18n.T(lang, "err.db"))
+			return
+		}
+		bot.Send(tgbotapi.NewMessage(chatID, i18n.T(lang, "ingredient.searching", good.Name)))
+		ShowCocktailsForIngredient(ctx, bot, chatID, database, strings.ToLower(good.Name), userID)
 
-	if strings.HasPrefix(data, "confirm_") {
+	case strings.HasPrefix(data, "confirm_"):
 		ingredient := strings.TrimPrefix(data, "confirm_")
 		bot.Request(tgbotapi.NewCallback(update.CallbackQuery.ID, ""))
-		bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("🔍 Ищу рецепты с ингредиентом: %s...", ingredient)))
-		ShowCocktailsForIngredient(bot, chatID, database, ingredient, userID)
-	} else if data == "reject" {
-		msg := tgbotapi.NewMessage(chatID, "Окей 🙂 напиши ингредиент ещё раз:")
+		bot.Send(tgbotapi.NewMessage(chatID, i18n.T(lang, "ingredient.searching", ingredient)))
+		ShowCocktailsForIngredient(ctx, bot, chatID, database, ingredient, userID)
+
+	case data == "reject":
+		msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "ingredient.retry"))
 		bot.Send(msg)
 	}
 }
 
-func ShowCocktailsForIngredient(bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, ingredient string, userID int64) {
-	cocktails, err := db.GetCocktailsBySimilarIngredients(database, ingredient)
+func ShowCocktailsForIngredient(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, ingredient string, userID int64) {
+	lang := langOf(userID)
+	cocktails, err := db.GetCocktailsBySimilarIngredients(ctx, database, ingredient, true)
 	if err != nil {
-		bot.Send(tgbotapi.NewMessage(chatID, "❌ Ошибка при поиске рецептов."))
+		bot.Send(tgbotapi.NewMessage(chatID, i18n.T(lang, "err.search")))
 		return
 	}
 
 	if len(cocktails) == 0 {
-		bot.Send(tgbotapi.NewMessage(chatID, "🥲 Коктейлей с таким ингредиентом не найдено."))
+		bot.Send(tgbotapi.NewMessage(chatID, i18n.T(lang, "ingredient.no_cocktails")))
 		return
 	}
+	rememberResults(userID, cocktails)
 
-	msg := tgbotapi.NewMessage(chatID,
-		fmt.Sprintf("🍸 Найдено %d рецептов с ингредиентом *%s*!", len(cocktails), ingredient))
+	msg := tgbotapi.NewMessage(chatID, i18n.N(lang, "ingredient.found", len(cocktails), ingredient))
 	msg.ParseMode = "Markdown"
-	msg.ReplyMarkup = IngredientMenuKeyboard()
+	msg.ReplyMarkup = IngredientMenuKeyboard(lang)
 	bot.Send(msg)
 }
 
-func HandleCallback(bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+func HandleCallback(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
 	data := update.CallbackQuery.Data
 	userID := update.CallbackQuery.From.ID
 	chatID := update.CallbackQuery.Message.Chat.ID
+	lang := langOf(userID)
 
 	// Ответим Telegram, чтобы убрать "часики" с кнопки
 	bot.Request(tgbotapi.NewCallback(update.CallbackQuery.ID, ""))
@@ -131,24 +237,52 @@ func HandleCallback(bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.
 
 	switch action {
 	case "fav":
-		err := db.AddFavorite(database, int64(userID), cocktailID)
+		err := db.AddFavorite(ctx, database, int64(userID), cocktailID)
 		if err != nil {
-			send(bot, chatID, "❌ Не удалось добавить в избранное.")
+			send(bot, chatID, i18n.T(lang, "fav.add_failed"))
 			return
 		}
-		send(bot, chatID, "💛 Добавлено в избранное!")
+		send(bot, chatID, i18n.T(lang, "fav.added"))
 
 	case "ignore":
-		err := db.AddIgnored(database, int64(userID), cocktailID)
+		err := db.AddIgnored(ctx, database, int64(userID), cocktailID)
 		if err != nil {
-			send(bot, chatID, "❌ Ошибка при скрытии коктейля.")
+			send(bot, chatID, i18n.T(lang, "ignore.failed"))
 			return
 		}
-		send(bot, chatID, "🚫 Коктейль скрыт.")
+		send(bot, chatID, i18n.T(lang, "ignore.done"))
+
+	case "cocktail":
+		SendCocktailCard(ctx, bot, chatID, database, cocktailID, userID)
+
+	case "similar":
+		showSimilar(ctx, bot, chatID, database, cocktailID, userID)
+
+	case "rate":
+		if len(parts) < 3 {
+			msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "rate.prompt"))
+			msg.ReplyMarkup = RatingKeyboard(cocktailID)
+			bot.Send(msg)
+			return
+		}
+		rating, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return
+		}
+		if err := db.SetRating(ctx, database, userID, cocktailID, rating); err != nil {
+			log.Println("Ошибка сохранения оценки:", err)
+			send(bot, chatID, i18n.T(lang, "rate.failed"))
+			return
+		}
+		send(bot, chatID, i18n.T(lang, "rate.saved", formatStars(rating)))
+
+	case "note":
+		withSession(userID, func(s *session) { s.pendingNote = cocktailID })
+		send(bot, chatID, i18n.T(lang, "note.prompt"))
 
 	case "next":
 		// Заглушка — позже добавим выбор следующего
-		send(bot, chatID, "⏭ Показать следующий пока не реализовано 🙂")
+		send(bot, chatID, i18n.T(lang, "next.todo"))
 	default:
 		log.Printf("Неизвестное действие: %s", data)
 	}
@@ -158,3 +292,14 @@ func send(bot *tgbotapi.BotAPI, chatID int64, text string) {
 	msg := tgbotapi.NewMessage(chatID, text)
 	bot.Send(msg)
 }
+
+// isMenuButton — текст совпадает с кнопкой меню на любом из языков
+// (клавиатура могла прийти до смены языка)
+func isMenuButton(text, key string) bool {
+	for _, l := range i18n.Supported() {
+		if text == i18n.T(l, key) {
+			return true
+		}
+	}
+	return false
+}
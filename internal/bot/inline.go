@@ -0,0 +1,151 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	inlineLimit     = 20  // сколько карточек предлагать (Telegram допускает до 50)
+	inlineCacheTime = 300 // сколько секунд Telegram может кэшировать ответ
+)
+
+// HandleInlineQuery — @bot негрони: карточки коктейлей, которые можно отправить в любой чат.
+// Ищет по названию и по ингредиенту
+func HandleInlineQuery(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	iq := update.InlineQuery
+	lang := langOf(iq.From.ID)
+
+	// язык карточек у каждого пользователя свой — ответ не должен кэшироваться для всех
+	answer := tgbotapi.InlineConfig{
+		InlineQueryID: iq.ID,
+		CacheTime:     inlineCacheTime,
+		IsPersonal:    true,
+		Results:       []interface{}{},
+	}
+
+	if query := strings.TrimSpace(iq.Query); query != "" {
+		cocktails, err := inlineSearch(ctx, database, query)
+		if err != nil {
+			log.Println("Ошибка inline-поиска:", err)
+		}
+		localize(ctx, database, lang, cocktails)
+		for _, c := range cocktails {
+			answer.Results = append(answer.Results, inlineResult(c, lang))
+		}
+	}
+
+	// пустой запрос или ничего не нашлось — подсказка со ссылкой на личный чат с ботом
+	if len(answer.Results) == 0 {
+		answer.SwitchPMText = i18n.T(lang, "inline.hint")
+		answer.SwitchPMParameter = "inline"
+	}
+
+	if _, err := bot.Request(answer); err != nil {
+		log.Println("Ошибка ответа на inline-запрос:", err)
+	}
+}
+
+// inlineSearch — сначала совпадения по названию, затем коктейли с ингредиентом,
+// если запрос распознан как ингредиент. Коктейли возвращаются с ингредиентами
+func inlineSearch(ctx context.Context, database *sql.DB, query string) ([]db.Cocktail, error) {
+	matches, err := db.SearchCocktailsByName(ctx, database, query, inlineLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make([]db.Cocktail, 0, len(matches))
+	for _, m := range matches {
+		byName = append(byName, m.Cocktail)
+	}
+
+	var byGood []db.Cocktail
+	if len(byName) < inlineLimit {
+		good, ok, err := db.ResolveGood(ctx, database, query)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if byGood, err = db.GetCocktailsByIngredients(ctx, database, []string{good.Name}, true); err != nil {
+				return nil, err
+			}
+		}
+	}
+	result := mergeInline(byName, byGood, inlineLimit)
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int, 0, len(result))
+	for _, c := range result {
+		ids = append(ids, c.ID)
+	}
+	ingredients, err := db.GetCocktailIngredients(ctx, database, ids)
+	if err != nil {
+		return nil, err
+	}
+	byCocktail := make(map[int][]db.CocktailIngredient)
+	for _, ing := range ingredients {
+		byCocktail[ing.CocktailID] = append(byCocktail[ing.CocktailID], ing)
+	}
+	for i := range result {
+		result[i].Ingredients = byCocktail[result[i].ID]
+	}
+	return result, nil
+}
+
+// mergeInline — совпадения по названию, за ними коктейли с ингредиентом;
+// без повторов и не больше limit
+func mergeInline(byName, byGood []db.Cocktail, limit int) []db.Cocktail {
+	seen := make(map[int]bool)
+	var result []db.Cocktail
+	for _, list := range [][]db.Cocktail{byName, byGood} {
+		for _, c := range list {
+			if len(result) == limit {
+				return result
+			}
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				result = append(result, c)
+			}
+		}
+	}
+	return result
+}
+
+// inlineResult — карточка коктейля для inline-ответа: фото с подписью,
+// а без фото или при слишком длинной подписи — текстовая статья.
+// Личные оценки и заметки в карточку не попадают: её увидят другие
+func inlineResult(c db.Cocktail, lang i18n.Lang) interface{} {
+	id := strconv.Itoa(c.ID)
+	text := formatCocktailCard(c, nil, 0, "", lang)
+	keyboard := InlineCardKeyboard(c, lang)
+
+	names := make([]string, 0, len(c.Ingredients))
+	for _, ing := range c.Ingredients {
+		names = append(names, ing.Good.Name)
+	}
+	description := strings.Join(names, ", ")
+
+	if c.ImageURL != "" && len([]rune(text)) <= captionLimit {
+		photo := tgbotapi.NewInlineQueryResultPhotoWithThumb(id, c.ImageURL, c.ImageURL)
+		photo.Title = c.Name
+		photo.Description = description
+		photo.Caption = text
+		photo.ReplyMarkup = &keyboard
+		return photo
+	}
+
+	article := tgbotapi.NewInlineQueryResultArticle(id, c.Name, text)
+	article.Description = description
+	article.ThumbURL = c.ImageURL
+	article.ReplyMarkup = &keyboard
+	return article
+}
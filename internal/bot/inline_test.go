@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestMergeInline(t *testing.T) {
+	list := func(ids ...int) []db.Cocktail {
+		var cs []db.Cocktail
+		for _, id := range ids {
+			cs = append(cs, db.Cocktail{ID: id})
+		}
+		return cs
+	}
+	tests := []struct {
+		name   string
+		byName []db.Cocktail
+		byGood []db.Cocktail
+		limit  int
+		want   []int
+	}{
+		{"ничего не нашлось", nil, nil, 5, nil},
+		{"только по названию", list(3, 1), nil, 5, []int{3, 1}},
+		{"только по ингредиенту", nil, list(2, 4), 5, []int{2, 4}},
+		{"название раньше ингредиента", list(7), list(2, 4), 5, []int{7, 2, 4}},
+		{"без повторов", list(1, 2), list(2, 3, 1), 5, []int{1, 2, 3}},
+		{"не больше лимита", list(1, 2), list(3, 4, 5), 3, []int{1, 2, 3}},
+		{"лимит исчерпан названиями", list(1, 2, 3), list(4), 3, []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		var got []int
+		for _, c := range mergeInline(tt.byName, tt.byGood, tt.limit) {
+			got = append(got, c.ID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("mergeInline(%s) = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestInlineResult(t *testing.T) {
+	cocktail := func(image, instructions string) db.Cocktail {
+		return db.Cocktail{
+			ID:           42,
+			Name:         "Негрони",
+			URL:          "https://ru.inshaker.com/cocktails/1",
+			ImageURL:     image,
+			Instructions: instructions,
+			Ingredients: []db.CocktailIngredient{
+				{Good: db.Good{Name: "Джин"}, Amount: "30", Unit: "мл"},
+				{Good: db.Good{Name: "Кампари"}, Amount: "30", Unit: "мл"},
+			},
+		}
+	}
+	const image = "https://ru.inshaker.com/negroni.jpg"
+
+	tests := []struct {
+		name      string
+		c         db.Cocktail
+		wantPhoto bool
+	}{
+		{"фото с подписью", cocktail(image, "Перемешай в стакане"), true},
+		{"без фото", cocktail("", "Перемешай в стакане"), false},
+		{"подпись длиннее лимита", cocktail(image, strings.Repeat("а", captionLimit)), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			switch r := inlineResult(tt.c, i18n.RU).(type) {
+			case tgbotapi.InlineQueryResultPhoto:
+				if !tt.wantPhoto {
+					t.Fatalf("inlineResult() — фото; want статью")
+				}
+				if r.ID != "42" || r.Title != "Негрони" || r.Description != "Джин, Кампари" || r.URL != image {
+					t.Errorf("фото заполнено неверно: %+v", r)
+				}
+				if len([]rune(r.Caption)) > captionLimit || r.ReplyMarkup == nil {
+					t.Errorf("подпись %d символов, клавиатура %v", len([]rune(r.Caption)), r.ReplyMarkup)
+				}
+			case tgbotapi.InlineQueryResultArticle:
+				if tt.wantPhoto {
+					t.Fatalf("inlineResult() — статья; want фото")
+				}
+				if r.ID != "42" || r.Title != "Негрони" || r.Description != "Джин, Кампари" || r.ThumbURL != tt.c.ImageURL {
+					t.Errorf("статья заполнена неверно: %+v", r)
+				}
+				if r.InputMessageContent == nil || r.ReplyMarkup == nil {
+					t.Errorf("у статьи нет текста или клавиатуры: %+v", r)
+				}
+			default:
+				t.Fatalf("inlineResult() вернул %T", r)
+			}
+		})
+	}
+}
@@ -1,17 +1,252 @@
 package bot
 
-import tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+import (
+	"fmt"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// listPageSize — строк-кнопок на странице длинного списка: у Telegram не больше
+// 100 кнопок в клавиатуре, а текст сообщения — не длиннее messageLimit
+const listPageSize = 20
+
+// paginate — границы страницы page в списке из n элементов. Номер страницы
+// приводится к допустимому: после удаления последней записи страница могла исчезнуть
+func paginate(n, page int) (from, to, current, pages int) {
+	pages = max(1, (n+listPageSize-1)/listPageSize)
+	current = min(max(page, 0), pages-1)
+	from = current * listPageSize
+	to = min(from+listPageSize, n)
+	return from, to, current, pages
+}
+
+// PageRow — ◀️ 2/5 ▶️ для листания; в callback — prefix и номер страницы.
+// Для одной страницы — nil
+func PageRow(prefix string, page, pages int) []tgbotapi.InlineKeyboardButton {
+	if pages <= 1 {
+		return nil
+	}
+	var row []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("◀️", fmt.Sprintf("%s%d", prefix, page-1)))
+	}
+	row = append(row, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d/%d", page+1, pages), fmt.Sprintf("%s%d", prefix, page)))
+	if page < pages-1 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("▶️", fmt.Sprintf("%s%d", prefix, page+1)))
+	}
+	return row
+}
 
 // Меню после выбора ингредиента
-func IngredientMenuKeyboard() tgbotapi.ReplyKeyboardMarkup {
+func IngredientMenuKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
 	return tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("👀 Показать"),
-			tgbotapi.NewKeyboardButton("➕ Добавить ингредиент"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "menu.show")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "menu.add")),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("⭐ Избранное"),
-			tgbotapi.NewKeyboardButton("🧹 Очистить ингредиенты"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "menu.favorites")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "menu.clear")),
 		),
 	)
 }
+
+// Выбор коктейлей и числа порций для списка покупок
+func ShoppingSelectKeyboard(cocktails []db.Cocktail, selected map[int]bool, servings int, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, c := range cocktails {
+		mark := "▫️ "
+		if selected[c.ID] {
+			mark = "✅ "
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(mark+c.Name, fmt.Sprintf("shop_t_%d", c.ID)),
+		))
+	}
+
+	rows = append(rows,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("➖", "shop_s_dec"),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("🍹 × %d", servings), "shop_s_noop"),
+			tgbotapi.NewInlineKeyboardButtonData("➕", "shop_s_inc"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "shop.next"), "shop_next"),
+		),
+	)
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// Отметка ингредиентов, которые уже есть дома
+func ShoppingHaveKeyboard(items []db.ShoppingItem, have map[int]bool, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	seen := make(map[int]bool)
+	for _, it := range items {
+		if seen[it.GoodID] {
+			continue
+		}
+		seen[it.GoodID] = true
+
+		mark := "🛒 "
+		if have[it.GoodID] {
+			mark = "🏠 "
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(mark+it.Name, fmt.Sprintf("shop_h_%d", it.GoodID)),
+		))
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "shop.done"), "shop_done"),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// Содержимое домашнего бара постранично: нажатие убирает ингредиент
+func BarKeyboard(goods []db.Good, page int) tgbotapi.InlineKeyboardMarkup {
+	from, to, page, pages := paginate(len(goods), page)
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, g := range goods[from:to] {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("❌ "+g.Name, fmt.Sprintf("bar_rm_%d_%d", g.ID, page)),
+		))
+	}
+	if nav := PageRow("bar_page_", page, pages); nav != nil {
+		rows = append(rows, nav)
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// Общий бар группы: нажатие убирает ингредиент, внизу — подбор коктейлей и очистка
+func PartyKeyboard(items []db.PartyItem, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, p := range items {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("❌ "+p.Name, fmt.Sprintf("party_rm_%d", p.ID)),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "party.btn_make"), "party_make"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "party.btn_clear"), "party_clear"),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// Кнопки под карточкой коктейля
+func CocktailCardKeyboard(cocktailID int, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.fav"), fmt.Sprintf("fav_%d", cocktailID)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.ignore"), fmt.Sprintf("ignore_%d", cocktailID)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.rate"), fmt.Sprintf("rate_%d", cocktailID)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.note"), fmt.Sprintf("note_%d", cocktailID)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.similar"), fmt.Sprintf("similar_%d", cocktailID)),
+		),
+	)
+}
+
+// Кнопки под карточкой в групповом чате: только то, что не зависит от нажавшего
+func GroupCardKeyboard(cocktailID int, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.similar"), fmt.Sprintf("similar_%d", cocktailID)),
+		),
+	)
+}
+
+// Список коктейлей кнопками: нажатие открывает карточку
+func CocktailListKeyboard(cocktails []db.Cocktail) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, c := range cocktails {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🍸 "+c.Name, fmt.Sprintf("cocktail_%d", c.ID)),
+		))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// Варианты для неизвестного ингредиента: от самых похожих, с числом рецептов
+func SuggestionsKeyboard(suggestions []db.GoodMatch, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, g := range suggestions {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("🍋 %s · 🍸 %d", g.Name, g.Recipes), fmt.Sprintf("good_%d", g.ID)),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "ingredient.none_of_these"), "reject"),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// Выбор оценки от 1 до 5
+func RatingKeyboard(cocktailID int) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for i := 1; i <= 5; i++ {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("%d⭐", i), fmt.Sprintf("rate_%d_%d", cocktailID, i)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
+
+// Переключение сортировки избранного
+func FavoritesSortKeyboard(byRating bool, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	if byRating {
+		return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "fav.by_date"), "favs_date"),
+		))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "fav.by_rating"), "favs_rating"),
+	))
+}
+
+// Действия администратора
+func AdminKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	button := func(action string) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "admin.btn_"+action), "admin_"+action)
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(button("scrape"), button("status")),
+		tgbotapi.NewInlineKeyboardRow(button("stats"), button("aliases")),
+		tgbotapi.NewInlineKeyboardRow(button("errors"), button("audit")),
+	)
+}
+
+// Кнопки под карточкой, отправленной через inline-режим. Сообщение живёт в чужом
+// чате, поэтому только ссылки — callback-кнопки там некому обрабатывать
+func InlineCardKeyboard(c db.Cocktail, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if c.URL != "" {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL(i18n.T(lang, "inline.btn_recipe"), c.URL)))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonSwitch(i18n.T(lang, "inline.btn_more"), "")))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// Подтверждение рассылки
+func BroadcastKeyboard(id int, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "broadcast.btn_send"), fmt.Sprintf("bcast_send_%d", id)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "broadcast.btn_cancel"), fmt.Sprintf("bcast_cancel_%d", id)),
+		),
+	)
+}
+
+// Выбор языка интерфейса
+func LanguageKeyboard() tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, l := range i18n.Supported() {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(i18n.T(l, "lang.name"), "lang_"+string(l)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
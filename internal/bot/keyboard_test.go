@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		n, page                      int
+		from, to, current, wantPages int
+	}{
+		{0, 0, 0, 0, 0, 1},
+		{5, 0, 0, 5, 0, 1},
+		{listPageSize, 0, 0, listPageSize, 0, 1},
+		{listPageSize + 1, 1, listPageSize, listPageSize + 1, 1, 2},
+		{45, 1, 20, 40, 1, 3},
+		{45, 2, 40, 45, 2, 3},
+		{45, 7, 40, 45, 2, 3}, // страницы больше нет — последняя
+		{45, -1, 0, 20, 0, 3}, // битый callback — первая
+		{40, 2, 20, 40, 1, 2}, // удалили последнюю запись на третьей странице
+	}
+	for _, tt := range tests {
+		from, to, current, pages := paginate(tt.n, tt.page)
+		if from != tt.from || to != tt.to || current != tt.current || pages != tt.wantPages {
+			t.Errorf("paginate(%d, %d) = %d, %d, %d, %d; want %d, %d, %d, %d",
+				tt.n, tt.page, from, to, current, pages, tt.from, tt.to, tt.current, tt.wantPages)
+		}
+	}
+}
+
+func TestPageRow(t *testing.T) {
+	tests := []struct {
+		page, pages int
+		want        []string // callback-данные кнопок
+	}{
+		{0, 1, nil},
+		{0, 3, []string{"x_0", "x_1"}},
+		{1, 3, []string{"x_0", "x_1", "x_2"}},
+		{2, 3, []string{"x_1", "x_2"}},
+	}
+	for _, tt := range tests {
+		row := PageRow("x_", tt.page, tt.pages)
+		var got []string
+		for _, b := range row {
+			got = append(got, *b.CallbackData)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("PageRow(%d, %d) = %v; want %v", tt.page, tt.pages, got, tt.want)
+		}
+	}
+}
+
+func TestBarKeyboardFitsTelegramLimits(t *testing.T) {
+	goods := make([]db.Good, 250)
+	for i := range goods {
+		goods[i] = db.Good{ID: i + 1, Name: fmt.Sprintf("Ингредиент %d", i+1)}
+	}
+
+	for page := 0; page < 13; page++ {
+		kb := BarKeyboard(goods, page)
+		buttons := 0
+		for _, row := range kb.InlineKeyboard {
+			buttons += len(row)
+			for _, b := range row {
+				if len(*b.CallbackData) > 64 {
+					t.Errorf("страница %d: callback %q длиннее 64 байт", page, *b.CallbackData)
+				}
+			}
+		}
+		if buttons > 100 {
+			t.Errorf("страница %d: %d кнопок; Telegram допускает 100", page, buttons)
+		}
+	}
+
+	last := BarKeyboard(goods, 12).InlineKeyboard
+	if got := *last[0][0].CallbackData; got != "bar_rm_241_12" {
+		t.Errorf("первая кнопка последней страницы = %q; want bar_rm_241_12", got)
+	}
+}
+
+func TestRatingKeyboard(t *testing.T) {
+	row := RatingKeyboard(42).InlineKeyboard[0]
+	if len(row) != 5 {
+		t.Fatalf("кнопок %d; want 5", len(row))
+	}
+	for i, b := range row {
+		if want := fmt.Sprintf("rate_42_%d", i+1); *b.CallbackData != want {
+			t.Errorf("кнопка %d: callback %q; want %q", i+1, *b.CallbackData, want)
+		}
+	}
+}
+
+func TestSuggestionsKeyboard(t *testing.T) {
+	suggestions := []db.GoodMatch{
+		{Good: db.Good{ID: 7, Name: "Лайм"}, Recipes: 120},
+		{Good: db.Good{ID: 9, Name: "Лаймовый сок"}, Recipes: 35},
+	}
+	rows := SuggestionsKeyboard(suggestions, i18n.RU).InlineKeyboard
+	want := []struct{ text, data string }{
+		{"🍋 Лайм · 🍸 120", "good_7"},
+		{"🍋 Лаймовый сок · 🍸 35", "good_9"},
+		{"🙅 Ничего из этого", "reject"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("строк %d; want %d", len(rows), len(want))
+	}
+	for i, w := range want {
+		if b := rows[i][0]; b.Text != w.text || *b.CallbackData != w.data {
+			t.Errorf("кнопка %d = %q/%q; want %q/%q", i, b.Text, *b.CallbackData, w.text, w.data)
+		}
+	}
+}
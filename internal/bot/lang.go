@@ -0,0 +1,48 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// HandleLang — /lang: выбор языка интерфейса; /lang en — сразу переключить
+func HandleLang(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+
+	if arg := strings.TrimSpace(update.Message.CommandArguments()); arg != "" {
+		setLang(ctx, bot, chatID, database, userID, i18n.Resolve(arg))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(langOf(userID), "lang.prompt"))
+	msg.ReplyMarkup = LanguageKeyboard()
+	bot.Send(msg)
+}
+
+// HandleLangCallback — lang_<код>: кнопка выбора языка
+func HandleLangCallback(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	cq := update.CallbackQuery
+	bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+	setLang(ctx, bot, cq.Message.Chat.ID, database, cq.From.ID, i18n.Resolve(strings.TrimPrefix(cq.Data, "lang_")))
+}
+
+// setLang — сохраняет язык в базе и сессии и подтверждает уже на новом языке
+func setLang(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, userID int64, lang i18n.Lang) {
+	if err := db.SetUserLanguage(ctx, database, userID, string(lang)); err != nil {
+		log.Println("Ошибка сохранения языка:", err)
+		send(bot, chatID, i18n.T(langOf(userID), "err.db"))
+		return
+	}
+	withSession(userID, func(s *session) { s.lang = lang })
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "lang.set"))
+	msg.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
+	bot.Send(msg)
+}
@@ -0,0 +1,34 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// TrackUser — обновляет запись пользователя перед обработкой любого апдейта,
+// чтобы остальные таблицы могли ссылаться на users, и запоминает язык интерфейса
+func TrackUser(ctx context.Context, database *sql.DB, update tgbotapi.Update) {
+	from := update.SentFrom()
+	if from == nil || from.IsBot {
+		return
+	}
+
+	chat := update.FromChat()
+	code, err := db.UpsertUser(ctx, database, db.User{
+		ID:           from.ID,
+		Username:     from.UserName,
+		FirstName:    from.FirstName,
+		LanguageCode: from.LanguageCode,
+	}, chat != nil && chat.IsPrivate())
+	if err != nil {
+		log.Printf("⚠️ Ошибка обновления пользователя %d: %v", from.ID, err)
+		code = from.LanguageCode
+	}
+	lang := i18n.Resolve(code)
+	withSession(from.ID, func(s *session) { s.lang = lang })
+}
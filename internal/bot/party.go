@@ -0,0 +1,279 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// bringPhrase — «принесу ром и лайм», «I'm bringing rum»: участник группы
+// сообщает, что принесёт. Остальные сообщения группы бот не читает
+var bringPhrase = regexp.MustCompile(`(?i)^(?:я\s+)?(?:принесу|несу|захвачу|i(?:'|’)?m\s+bringing|i(?:'|’)?ll\s+bring|i\s+will\s+bring)\s+(.+?)[.!]*$`)
+
+// partySeparator — разделители в списке ингредиентов: «ром, лайм и мяту»
+var partySeparator = regexp.MustCompile(`(?i)\s*,\s*|\s+(?:и|and)\s+`)
+
+// partyMatchMin — порог сходства для фраз из переписки: выше обычного,
+// чтобы «принесу хорошее настроение» не стало ингредиентом
+const partyMatchMin = 0.5
+
+// ruEndings — падежные окончания, которые отрезает stemRU (длинные раньше коротких)
+var ruEndings = []string{"ую", "юю", "ой", "ей", "ом", "ем", "ов", "ев", "ая", "яя", "ые", "ие", "у", "ю", "а", "я", "ы", "и", "е"}
+
+// stemRU — грубая основа русских слов: «свежую мяту» → «свеж мят».
+// Основа короче трёх букв не отрезается, чтобы «ром» остался ромом
+func stemRU(s string) string {
+	words := strings.Fields(strings.ToLower(s))
+	for i, w := range words {
+		for _, end := range ruEndings {
+			if stem, ok := strings.CutSuffix(w, end); ok && utf8.RuneCountInString(stem) >= 3 {
+				words[i] = stem
+				break
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// findSpoken — ингредиент из живой речи: «мяту», «лайма». Сначала точное
+// совпадение, затем похожее название и похожее по основе слова
+func findSpoken(ctx context.Context, database *sql.DB, name string) (db.Good, bool, error) {
+	good, ok, err := db.ResolveGood(ctx, database, name)
+	if err != nil || ok {
+		return good, ok, err
+	}
+	for _, query := range []string{name, stemRU(name)} {
+		matches, err := db.SuggestGoods(ctx, database, query, partyMatchMin, 1)
+		if err != nil {
+			return good, false, err
+		}
+		if len(matches) > 0 {
+			return matches[0].Good, true, nil
+		}
+	}
+	return good, false, nil
+}
+
+// isGroup — групповой чат (обычная группа или супергруппа)
+func isGroup(chat *tgbotapi.Chat) bool {
+	return chat != nil && (chat.IsGroup() || chat.IsSuperGroup())
+}
+
+// isForOtherBot — команда вида /start@other_bot: в группе с несколькими ботами
+// отвечать на неё должен тот, кому она адресована
+func isForOtherBot(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) bool {
+	_, at, ok := strings.Cut(msg.CommandWithAt(), "@")
+	return ok && !strings.EqualFold(at, bot.Self.UserName)
+}
+
+// HandlePrivateOnly — личная команда в группе: её состояние привязано к пользователю,
+// а не к чату, поэтому отправляем в личку
+func HandlePrivateOnly(bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	msg := update.Message
+	send(bot, msg.Chat.ID, i18n.T(langOf(msg.From.ID), "group.private_command", msg.Command(), bot.Self.UserName))
+}
+
+// HandleGroupMessage — обычное сообщение в группе. Бот приветствует группу,
+// когда его добавили, и подхватывает фразы «принесу ром»; остальное игнорирует,
+// чтобы не отвечать на каждую реплику
+func HandleGroupMessage(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	msg := update.Message
+	for _, member := range msg.NewChatMembers {
+		if member.ID == bot.Self.ID {
+			send(bot, msg.Chat.ID, i18n.T(langOf(msg.From.ID), "group.start"))
+			return
+		}
+	}
+
+	if m := bringPhrase.FindStringSubmatch(strings.TrimSpace(msg.Text)); m != nil {
+		bringToParty(ctx, bot, msg, database, m[1], true)
+	}
+}
+
+// HandleBring — /bring ром, лайм: добавить ингредиенты в общий бар группы
+func HandleBring(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	msg := update.Message
+	lang := langOf(msg.From.ID)
+	if !isGroup(msg.Chat) {
+		send(bot, msg.Chat.ID, i18n.T(lang, "party.group_only"))
+		return
+	}
+
+	args := strings.TrimSpace(msg.CommandArguments())
+	if args == "" {
+		send(bot, msg.Chat.ID, i18n.T(lang, "party.bring_usage"))
+		return
+	}
+	bringToParty(ctx, bot, msg, database, args, false)
+}
+
+// bringToParty — ищет ингредиенты из списка и записывает их за автором сообщения.
+// strict — для фраз из переписки: слова в падеже («мяту») и высокий порог
+// сходства вместо самого похожего ингредиента. Нераспознанное перечисляется в ответе
+func bringToParty(ctx context.Context, bot *tgbotapi.BotAPI, msg *tgbotapi.Message, database *sql.DB, list string, strict bool) {
+	chatID := msg.Chat.ID
+	lang := langOf(msg.From.ID)
+
+	var added, unknown []string
+	for _, name := range partySeparator.Split(list, -1) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		find := db.FindGood
+		if strict {
+			find = findSpoken
+		}
+		good, ok, err := find(ctx, database, name)
+		if err != nil {
+			log.Println("Ошибка поиска ингредиента для вечеринки:", err)
+			send(bot, chatID, i18n.T(lang, "err.db"))
+			return
+		}
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		if good.IsCategory {
+			send(bot, chatID, categoryHint(ctx, database, good, lang))
+			continue
+		}
+		if err := db.AddPartyItem(ctx, database, chatID, msg.From.ID, good.ID); err != nil {
+			log.Println("Ошибка добавления в бар вечеринки:", err)
+			send(bot, chatID, i18n.T(lang, "bar.add_failed"))
+			return
+		}
+		added = append(added, good.Name)
+	}
+
+	var reply []string
+	if len(added) > 0 {
+		reply = append(reply, i18n.T(lang, "party.brings", msg.From.FirstName, strings.Join(added, ", ")))
+	}
+	if len(unknown) > 0 {
+		reply = append(reply, i18n.T(lang, "bar.unknown", strings.Join(unknown, ", ")))
+	}
+	if len(added) > 0 {
+		reply = append(reply, i18n.T(lang, "party.hint"))
+	}
+	if len(reply) > 0 {
+		send(bot, chatID, strings.Join(reply, "\n"))
+	}
+}
+
+// HandleParty — /party: общий бар группы и кто что принесёт
+func HandleParty(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	msg := update.Message
+	lang := langOf(msg.From.ID)
+	if !isGroup(msg.Chat) {
+		send(bot, msg.Chat.ID, i18n.T(lang, "party.group_only"))
+		return
+	}
+
+	items, err := db.GetParty(ctx, database, msg.Chat.ID)
+	if err != nil {
+		log.Println("Ошибка получения бара вечеринки:", err)
+		send(bot, msg.Chat.ID, i18n.T(lang, "err.db"))
+		return
+	}
+	if len(items) == 0 {
+		send(bot, msg.Chat.ID, i18n.T(lang, "party.empty"))
+		return
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, formatParty(items, lang))
+	reply.ReplyMarkup = PartyKeyboard(items, lang)
+	bot.Send(reply)
+}
+
+// HandlePartyCallback — party_rm_<good_id>, party_make, party_subs, party_clear
+func HandlePartyCallback(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	cq := update.CallbackQuery
+	chatID := cq.Message.Chat.ID
+	lang := langOf(cq.From.ID)
+
+	switch action := strings.TrimPrefix(cq.Data, "party_"); {
+	case action == "make" || action == "subs":
+		bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+		showPartyMakeable(ctx, bot, chatID, database, lang, action == "subs")
+
+	case action == "clear":
+		if err := db.ClearParty(ctx, database, chatID); err != nil {
+			log.Println("Ошибка очистки бара вечеринки:", err)
+			bot.Request(tgbotapi.NewCallback(cq.ID, i18n.T(lang, "err.db")))
+			return
+		}
+		bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+		bot.Send(tgbotapi.NewEditMessageText(chatID, cq.Message.MessageID, i18n.T(lang, "party.cleared")))
+
+	case strings.HasPrefix(action, "rm_"):
+		goodID, err := strconv.Atoi(strings.TrimPrefix(action, "rm_"))
+		if err != nil {
+			bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+			return
+		}
+		if err := db.RemovePartyItem(ctx, database, chatID, goodID); err != nil {
+			log.Println("Ошибка удаления из бара вечеринки:", err)
+			bot.Request(tgbotapi.NewCallback(cq.ID, i18n.T(lang, "bar.remove_failed")))
+			return
+		}
+		bot.Request(tgbotapi.NewCallback(cq.ID, i18n.T(lang, "party.removed")))
+
+		items, err := db.GetParty(ctx, database, chatID)
+		if err != nil {
+			log.Println("Ошибка получения бара вечеринки:", err)
+			return
+		}
+		if len(items) == 0 {
+			bot.Send(tgbotapi.NewEditMessageText(chatID, cq.Message.MessageID, i18n.T(lang, "party.empty")))
+			return
+		}
+		bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, cq.Message.MessageID, formatParty(items, lang), PartyKeyboard(items, lang)))
+
+	default:
+		bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+	}
+}
+
+// showPartyMakeable — что компания может приготовить из общего бара
+func showPartyMakeable(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, lang i18n.Lang, withSubstitutes bool) {
+	cocktails, err := db.GetPartyMakeableCocktails(ctx, database, chatID, makeableMaxMissing, makeableLimit, withSubstitutes)
+	if err != nil {
+		log.Println("Ошибка подбора коктейлей для вечеринки:", err)
+		send(bot, chatID, i18n.T(lang, "err.db"))
+		return
+	}
+	if len(cocktails) == 0 {
+		send(bot, chatID, i18n.T(lang, "party.nothing"))
+		return
+	}
+	localizeMakeable(ctx, database, lang, cocktails)
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "party.make_title")+"\n\n"+formatMakeable(cocktails, withSubstitutes, lang))
+	if !withSubstitutes {
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "make.subs_button"), "party_subs"),
+			),
+		)
+	}
+	bot.Send(msg)
+}
+
+// formatParty — список «ингредиент — кто принесёт»
+func formatParty(items []db.PartyItem, lang i18n.Lang) string {
+	lines := []string{i18n.T(lang, "party.title")}
+	for _, p := range items {
+		lines = append(lines, i18n.T(lang, "party.item", p.Name, p.UserName))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestBringPhrase(t *testing.T) {
+	tests := []struct {
+		text string
+		want string // "" — фраза не распознана
+	}{
+		{"принесу ром и лайм", "ром и лайм"},
+		{"Я принесу джин, тоник!", "джин, тоник"},
+		{"несу мяту.", "мяту"},
+		{"захвачу лёд", "лёд"},
+		{"I'm bringing rum", "rum"},
+		{"I’m bringing limes and mint!!", "limes and mint"},
+		{"im bringing gin", "gin"},
+		{"I'll bring vodka", "vodka"},
+		{"I will bring tonic", "tonic"},
+		{"кто принесёт ром?", ""},
+		{"завтра принесу ром", ""},
+		{"принесу", ""},
+		{"I'm bringing", ""},
+		{"привет всем", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if m := bringPhrase.FindStringSubmatch(tt.text); m != nil {
+			got = m[1]
+		}
+		if got != tt.want {
+			t.Errorf("bringPhrase(%q) = %q; want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestPartySeparator(t *testing.T) {
+	tests := []struct {
+		list string
+		want []string
+	}{
+		{"ром", []string{"ром"}},
+		{"ром, лайм и мяту", []string{"ром", "лайм", "мяту"}},
+		{"джин ,тоник И лёд", []string{"джин", "тоник", "лёд"}},
+		{"rum and lime, mint", []string{"rum", "lime", "mint"}},
+		{"сироп имбирный", []string{"сироп имбирный"}},
+	}
+	for _, tt := range tests {
+		if got := partySeparator.Split(tt.list, -1); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("partySeparator.Split(%q) = %q; want %q", tt.list, got, tt.want)
+		}
+	}
+}
+
+func TestIsForOtherBot(t *testing.T) {
+	bot := &tgbotapi.BotAPI{Self: tgbotapi.User{UserName: "InshakerBot"}}
+	command := func(text string) *tgbotapi.Message {
+		cmd, _, _ := strings.Cut(text, " ")
+		return &tgbotapi.Message{
+			Text:     text,
+			Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(cmd)}},
+		}
+	}
+
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"/party", false},
+		{"/bring ром", false},
+		{"/party@InshakerBot", false},
+		{"/party@inshakerbot", false},
+		{"/party@other_bot", true},
+		{"/bring@other_bot ром", true},
+	}
+	for _, tt := range tests {
+		if got := isForOtherBot(bot, command(tt.text)); got != tt.want {
+			t.Errorf("isForOtherBot(%q) = %v; want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestStemRU(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"мяту", "мят"},
+		{"лайма", "лайм"},
+		{"водку", "водк"},
+		{"Свежую мяту", "свеж мят"},
+		{"ром", "ром"},
+		{"лёд", "лёд"},
+		{"джин", "джин"},
+		{"rum", "rum"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := stemRU(tt.in); got != tt.want {
+			t.Errorf("stemRU(%q) = %q; want %q", tt.in, got, tt.want)
+		}
+	}
+}
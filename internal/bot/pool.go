@@ -0,0 +1,156 @@
+package bot
+
+import (
+	"errors"
+	"log"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Pool — параллельная обработка апдейтов. У каждого воркера своя очередь,
+// и чат всегда попадает к одному и тому же воркеру, поэтому апдейты одного
+// чата обрабатываются по порядку, а медленный запрос не задерживает остальные чаты
+type Pool struct {
+	queues []chan tgbotapi.Update
+	handle func(tgbotapi.Update)
+	wg     sync.WaitGroup
+
+	mu     sync.RWMutex // Submit держит на чтение, Close — на запись
+	closed bool
+
+	depth     atomic.Int64 // апдейтов в очередях сейчас
+	maxDepth  atomic.Int64 // максимум depth с запуска
+	processed atomic.Int64 // обработано всего
+	waits     atomic.Int64 // сколько раз Submit ждал из-за полной очереди
+}
+
+// PoolStats — снимок метрик очереди
+type PoolStats struct {
+	Workers   int
+	Depth     int64
+	MaxDepth  int64
+	Processed int64
+	Waits     int64
+}
+
+// NewPool — запускает workers воркеров с очередью queueSize у каждого
+func NewPool(workers, queueSize int, handle func(tgbotapi.Update)) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 1 {
+		queueSize = 1
+	}
+
+	p := &Pool{handle: handle}
+	for i := 0; i < workers; i++ {
+		q := make(chan tgbotapi.Update, queueSize)
+		p.queues = append(p.queues, q)
+		p.wg.Add(1)
+		go p.work(q)
+	}
+	return p
+}
+
+// ErrPoolClosed — апдейт пришёл после Close
+var ErrPoolClosed = errors.New("очередь апдейтов закрыта")
+
+// Submit — ставит апдейт в очередь его чата. Если очередь полна, ждёт:
+// так polling перестаёт забирать апдейты, а webhook отвечает Telegram позже.
+// После Close возвращает ErrPoolClosed, апдейт не принимается
+func (p *Pool) Submit(update tgbotapi.Update) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+	q := p.queues[chatKey(update)%uint64(len(p.queues))]
+
+	depth := p.depth.Add(1)
+	for {
+		max := p.maxDepth.Load()
+		if depth <= max || p.maxDepth.CompareAndSwap(max, depth) {
+			break
+		}
+	}
+
+	select {
+	case q <- update:
+	default:
+		p.waits.Add(1)
+		q <- update
+	}
+	return nil
+}
+
+// Close — перестаёт принимать апдейты и ждёт, пока воркеры разберут очереди.
+// Submit, ждущие места в очереди, успевают её дождаться: воркеры работают до close
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, q := range p.queues {
+			close(q)
+		}
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// Stats — текущие метрики
+func (p *Pool) Stats() PoolStats {
+	return PoolStats{
+		Workers:   len(p.queues),
+		Depth:     p.depth.Load(),
+		MaxDepth:  p.maxDepth.Load(),
+		Processed: p.processed.Load(),
+		Waits:     p.waits.Load(),
+	}
+}
+
+// LogStats — раз в interval пишет метрики в лог, если с прошлого раза что-то обработано
+func (p *Pool) LogStats(interval time.Duration) {
+	var last int64
+	for range time.Tick(interval) {
+		s := p.Stats()
+		if s.Processed == last {
+			continue
+		}
+		last = s.Processed
+		log.Printf("📊 Очередь апдейтов: сейчас %d, максимум %d, обработано %d, ожиданий %d (воркеров %d)",
+			s.Depth, s.MaxDepth, s.Processed, s.Waits, s.Workers)
+	}
+}
+
+// work — обрабатывает очередь одного воркера. Паника в обработчике
+// не должна останавливать остальные чаты этого воркера
+func (p *Pool) work(q chan tgbotapi.Update) {
+	defer p.wg.Done()
+	for update := range q {
+		p.depth.Add(-1)
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("❌ Паника при обработке апдейта %d: %v\n%s", update.UpdateID, r, debug.Stack())
+				}
+			}()
+			p.handle(update)
+		}()
+		p.processed.Add(1)
+	}
+}
+
+// chatKey — ключ упорядочивания: чат, а для апдейтов без чата — пользователь
+func chatKey(update tgbotapi.Update) uint64 {
+	if chat := update.FromChat(); chat != nil {
+		return uint64(chat.ID)
+	}
+	if from := update.SentFrom(); from != nil {
+		return uint64(from.ID)
+	}
+	return uint64(update.UpdateID)
+}
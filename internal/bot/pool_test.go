@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// chatUpdate — сообщение в чат chatID; порядок внутри чата задаёт UpdateID
+func chatUpdate(id int, chatID int64) tgbotapi.Update {
+	return tgbotapi.Update{
+		UpdateID: id,
+		Message:  &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: chatID}},
+	}
+}
+
+func TestPoolPerChatOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		workers   int
+		queueSize int
+		chats     int
+		perChat   int
+	}{
+		{"один воркер", 1, 4, 5, 50},
+		{"воркеров меньше, чем чатов", 4, 2, 13, 50},
+		{"воркеров больше, чем чатов", 16, 1, 3, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			seen := make(map[int64][]int)
+			pool := NewPool(tt.workers, tt.queueSize, func(u tgbotapi.Update) {
+				if u.UpdateID%7 == 0 {
+					time.Sleep(time.Millisecond) // медленный апдейт не должен обгоняться следующими
+				}
+				mu.Lock()
+				seen[u.Message.Chat.ID] = append(seen[u.Message.Chat.ID], u.UpdateID)
+				mu.Unlock()
+			})
+
+			// апдейты разных чатов перемешаны, как их присылает Telegram
+			id := 0
+			for i := 0; i < tt.perChat; i++ {
+				for c := 1; c <= tt.chats; c++ {
+					id++
+					if err := pool.Submit(chatUpdate(id, int64(-c))); err != nil {
+						t.Fatalf("Submit: %v", err)
+					}
+				}
+			}
+			pool.Close()
+
+			if len(seen) != tt.chats {
+				t.Fatalf("обработано чатов %d; want %d", len(seen), tt.chats)
+			}
+			for chatID, ids := range seen {
+				if len(ids) != tt.perChat {
+					t.Errorf("чат %d: обработано %d апдейтов; want %d", chatID, len(ids), tt.perChat)
+				}
+				for i := 1; i < len(ids); i++ {
+					if ids[i] <= ids[i-1] {
+						t.Errorf("чат %d: апдейт %d обработан после %d", chatID, ids[i], ids[i-1])
+						break
+					}
+				}
+			}
+			if s := pool.Stats(); s.Processed != int64(tt.chats*tt.perChat) || s.Depth != 0 {
+				t.Errorf("Stats() = %+v; want Processed %d, Depth 0", s, tt.chats*tt.perChat)
+			}
+		})
+	}
+}
+
+func TestPoolSubmitAfterClose(t *testing.T) {
+	pool := NewPool(2, 1, func(tgbotapi.Update) {})
+	pool.Close()
+	pool.Close() // повторный Close не паникует
+
+	if err := pool.Submit(chatUpdate(1, 1)); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Submit после Close = %v; want %v", err, ErrPoolClosed)
+	}
+}
+
+func TestPoolRecoversPanic(t *testing.T) {
+	var mu sync.Mutex
+	var handled []int
+	pool := NewPool(1, 4, func(u tgbotapi.Update) {
+		if u.UpdateID == 1 {
+			panic("сбой обработчика")
+		}
+		mu.Lock()
+		handled = append(handled, u.UpdateID)
+		mu.Unlock()
+	})
+	for id := 1; id <= 3; id++ {
+		if err := pool.Submit(chatUpdate(id, 5)); err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	}
+	pool.Close()
+
+	if len(handled) != 2 || handled[0] != 2 || handled[1] != 3 {
+		t.Errorf("после паники обработаны %v; want [2 3]", handled)
+	}
+}
@@ -0,0 +1,91 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// HandleRandom — /random [ингредиент | #тег | крепость]: случайный коктейль
+func HandleRandom(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	lang := langOf(userID)
+
+	var filter db.RandomFilter
+	arg := strings.TrimSpace(update.Message.CommandArguments())
+	switch {
+	case arg == "":
+	case strings.HasPrefix(arg, "#"):
+		filter.Tag = strings.TrimPrefix(arg, "#")
+	default:
+		if strength, ok := db.ParseStrength(arg); ok {
+			filter.Strength = strength
+			break
+		}
+		good, ok, err := db.FindGood(ctx, database, arg)
+		if err != nil {
+			log.Println("Ошибка поиска ингредиента:", err)
+			send(bot, chatID, i18n.T(lang, "err.db"))
+			return
+		}
+		if !ok {
+			send(bot, chatID, i18n.T(lang, "ingredient.not_found"))
+			return
+		}
+		filter.GoodID = good.ID
+	}
+
+	withSession(userID, func(s *session) {
+		s.randomFilter = filter
+		s.randomShown = nil
+	})
+	sendRandom(ctx, bot, chatID, database, userID)
+}
+
+// HandleRandomCallback — random_more: ещё один случайный коктейль с тем же фильтром
+func HandleRandomCallback(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	cq := update.CallbackQuery
+	bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+	if cq.Data == "random_more" {
+		sendRandom(ctx, bot, cq.Message.Chat.ID, database, cq.From.ID)
+	}
+}
+
+// sendRandom — выбирает коктейль, которого ещё не было в этой сессии
+func sendRandom(ctx context.Context, bot *tgbotapi.BotAPI, chatID int64, database *sql.DB, userID int64) {
+	lang := langOf(userID)
+	var filter db.RandomFilter
+	var shown []int
+	withSession(userID, func(s *session) {
+		filter = s.randomFilter
+		shown = append(shown, s.randomShown...)
+	})
+
+	c, err := db.GetRandomCocktail(ctx, database, userID, filter, shown)
+	if err == sql.ErrNoRows && len(shown) > 0 {
+		// всё подходящее уже показано — начинаем круг заново
+		send(bot, chatID, i18n.T(lang, "random.cycle"))
+		shown = nil
+		c, err = db.GetRandomCocktail(ctx, database, userID, filter, nil)
+	}
+	if err == sql.ErrNoRows {
+		send(bot, chatID, i18n.T(lang, "random.none"))
+		return
+	}
+	if err != nil {
+		log.Println("Ошибка выбора случайного коктейля:", err)
+		send(bot, chatID, i18n.T(lang, "err.db"))
+		return
+	}
+
+	withSession(userID, func(s *session) { s.randomShown = append(shown, c.ID) })
+
+	SendCocktailCard(ctx, bot, chatID, database, c.ID, userID,
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "random.more"), "random_more")))
+}
@@ -0,0 +1,55 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const recommendLimit = 8 // сколько рекомендаций показывать
+
+// HandleRecommend — /recommend: подборка по избранному и скрытым коктейлям
+func HandleRecommend(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	lang := langOf(userID)
+
+	recs, err := db.GetRecommendations(ctx, database, userID, recommendLimit)
+	if err != nil {
+		log.Println("Ошибка подбора рекомендаций:", err)
+		send(bot, chatID, i18n.T(lang, "err.db"))
+		return
+	}
+	if len(recs) == 0 {
+		send(bot, chatID, i18n.T(lang, "recommend.empty"))
+		return
+	}
+
+	list := make([]db.Cocktail, 0, len(recs))
+	because := make([]db.Cocktail, 0, len(recs))
+	for _, r := range recs {
+		list = append(list, r.Cocktail)
+		because = append(because, db.Cocktail{ID: r.BecauseID, Name: r.Because})
+	}
+	localize(ctx, database, lang, list)
+	localize(ctx, database, lang, because)
+
+	lines := []string{i18n.T(lang, "recommend.title")}
+	for i, r := range recs {
+		if r.Because != "" {
+			lines = append(lines, i18n.T(lang, "recommend.because", list[i].Name, because[i].Name))
+		} else {
+			lines = append(lines, i18n.T(lang, "list.item", list[i].Name))
+		}
+	}
+	rememberResults(userID, list)
+
+	msg := tgbotapi.NewMessage(chatID, strings.Join(lines, "\n"))
+	msg.ReplyMarkup = CocktailListKeyboard(list)
+	bot.Send(msg)
+}
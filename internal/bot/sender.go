@@ -0,0 +1,278 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Ограничения Telegram на исходящие сообщения
+const (
+	globalSendInterval  = time.Second / 30 // не больше 30 сообщений в секунду на бота
+	privateSendInterval = time.Second      // в личный чат — не чаще раза в секунду
+	groupSendInterval   = 3 * time.Second  // в группу — не больше 20 в минуту
+	maxSendAttempts     = 5
+	maxRetryAfter       = time.Minute // дольше не ждём, считаем отправку неудачной
+)
+
+// Sender — HTTP-клиент для tgbotapi, через который идут все запросы бота.
+// Исходящие сообщения выстраиваются в очередь с учётом общих и по-чатовых
+// лимитов Telegram, 429 повторяются через retry_after, а постоянные ошибки
+// логируются. Вызов Send ждёт своей очереди, поэтому обработчики менять не нужно
+type Sender struct {
+	client *http.Client
+
+	// OnBlocked вызывается, когда пользователь заблокировал бота или удалил аккаунт
+	OnBlocked func(chatID int64)
+
+	mu       sync.Mutex
+	nextAny  time.Time           // когда можно отправить следующее сообщение вообще
+	nextChat map[int64]time.Time // когда можно отправить следующее сообщение в чат
+
+	stop     chan struct{} // закрывается Stop: ожидание очереди прерывается
+	stopOnce sync.Once
+}
+
+// NewSender — клиент с таймаутом на запрос. Таймаут должен быть больше
+// таймаута long polling, иначе getUpdates будет обрываться
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{
+		client:   &http.Client{Timeout: timeout},
+		nextChat: make(map[int64]time.Time),
+		stop:     make(chan struct{}),
+	}
+}
+
+// errSenderStopped — отправка отменена остановкой бота
+var errSenderStopped = errors.New("отправка прервана: бот останавливается")
+
+// Stop — прерывает все ожидания очереди и 429. tgbotapi создаёт запросы без
+// контекста, поэтому при остановке бота ожидание прерывается только так
+func (s *Sender) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// apiError — тело ответа Telegram с ошибкой
+type apiError struct {
+	OK          bool   `json:"ok"`
+	ErrorCode   int    `json:"error_code"`
+	Description string `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+// Do — реализация tgbotapi.HTTPClient
+func (s *Sender) Do(req *http.Request) (*http.Response, error) {
+	method := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+	if !isOutgoing(method) {
+		return s.client.Do(req)
+	}
+
+	// тело читаем целиком, чтобы можно было повторить запрос
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	chatID := requestChatID(req.Header.Get("Content-Type"), body)
+
+	for attempt := 1; ; attempt++ {
+		if err := s.wait(req.Context(), chatID); err != nil {
+			return nil, err
+		}
+
+		r := req.Clone(req.Context())
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+
+		resp, err := s.client.Do(r)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+
+		var apiErr apiError
+		json.Unmarshal(data, &apiErr)
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests && attempt < maxSendAttempts:
+			retry := time.Duration(apiErr.Parameters.RetryAfter) * time.Second
+			if retry <= 0 {
+				retry = time.Second
+			}
+			if retry > maxRetryAfter {
+				log.Printf("❌ %s в чат %d: Telegram просит ждать %v — не отправлено", method, chatID, retry)
+				return resp, nil
+			}
+			log.Printf("⏳ %s в чат %d: 429, повтор через %v (попытка %d)", method, chatID, retry, attempt)
+			s.delay(chatID, retry)
+			continue
+
+		case resp.StatusCode >= 500 && attempt < maxSendAttempts:
+			log.Printf("⏳ %s в чат %d: HTTP %d, повтор (попытка %d)", method, chatID, resp.StatusCode, attempt)
+			s.delay(chatID, time.Duration(attempt)*time.Second)
+			continue
+
+		case resp.StatusCode == http.StatusForbidden && chatID > 0 && isBlockedError(apiErr.Description):
+			log.Printf("🚫 Пользователь %d недоступен: %s", chatID, apiErr.Description)
+			if s.OnBlocked != nil {
+				s.OnBlocked(chatID)
+			}
+
+		default:
+			log.Printf("❌ %s в чат %d не отправлено: HTTP %d %s", method, chatID, resp.StatusCode, apiErr.Description)
+		}
+		return resp, nil
+	}
+}
+
+// wait — ждёт, пока не освободятся общий и по-чатовый лимиты, и занимает слот.
+// Отмена ctx или Stop прерывают ожидание
+func (s *Sender) wait(ctx context.Context, chatID int64) error {
+	s.mu.Lock()
+	now := time.Now()
+	at := now
+	if s.nextAny.After(at) {
+		at = s.nextAny
+	}
+	if next := s.nextChat[chatID]; chatID != 0 && next.After(at) {
+		at = next
+	}
+	s.nextAny = at.Add(globalSendInterval)
+	if chatID != 0 {
+		s.nextChat[chatID] = at.Add(chatInterval(chatID))
+	}
+	// старые записи о чатах больше не ограничивают — чистим, чтобы карта не росла
+	if len(s.nextChat) > 10000 {
+		for id, next := range s.nextChat {
+			if next.Before(now) {
+				delete(s.nextChat, id)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	d := time.Until(at)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.stop:
+		return errSenderStopped
+	}
+}
+
+// delay — откладывает следующую отправку в чат (а для 429 без чата — все отправки)
+func (s *Sender) delay(chatID int64, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	at := time.Now().Add(d)
+	if chatID == 0 {
+		s.nextAny = at
+		return
+	}
+	if s.nextChat[chatID].Before(at) {
+		s.nextChat[chatID] = at
+	}
+}
+
+// chatInterval — минимальный интервал между сообщениями в чат
+func chatInterval(chatID int64) time.Duration {
+	if chatID < 0 {
+		return groupSendInterval
+	}
+	return privateSendInterval
+}
+
+// isOutgoing — методы, которые отправляют или меняют сообщения и попадают под лимиты
+func isOutgoing(method string) bool {
+	return strings.HasPrefix(method, "send") ||
+		strings.HasPrefix(method, "edit") ||
+		method == "copyMessage" || method == "forwardMessage"
+}
+
+// isPermanentSendError — Telegram отклонил запрос (4xx, кроме 429): повтор не поможет.
+// Сетевые ошибки, 5xx и 429 после всех попыток Sender считаются временными
+func isPermanentSendError(err error) bool {
+	var apiErr *tgbotapi.Error
+	return errors.As(err, &apiErr) &&
+		apiErr.Code >= 400 && apiErr.Code < 500 && apiErr.Code != http.StatusTooManyRequests
+}
+
+// isBlockedError — ошибки 403, после которых писать пользователю бесполезно
+func isBlockedError(description string) bool {
+	d := strings.ToLower(description)
+	return strings.Contains(d, "blocked by the user") ||
+		strings.Contains(d, "user is deactivated") ||
+		strings.Contains(d, "chat not found")
+}
+
+// requestChatID — chat_id из тела запроса (form или multipart), 0 если его нет
+func requestChatID(contentType string, body []byte) int64 {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return 0
+	}
+
+	var value string
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return 0
+		}
+		value = form.Get("chat_id")
+	case strings.HasPrefix(mediaType, "multipart/"):
+		mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				return 0
+			}
+			if part.FormName() == "chat_id" {
+				data, _ := io.ReadAll(part)
+				value = string(data)
+				break
+			}
+		}
+	}
+
+	id, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
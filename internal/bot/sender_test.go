@@ -0,0 +1,103 @@
+package bot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// multipartBody — тело запроса с файлом, как его собирает tgbotapi для sendPhoto
+func multipartBody(t *testing.T, fields map[string]string) (string, []byte) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	fw, err := w.CreateFormFile("photo", "photo.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write([]byte("\xff\xd8\xff"))
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return w.FormDataContentType(), buf.Bytes()
+}
+
+func TestRequestChatID(t *testing.T) {
+	const form = "application/x-www-form-urlencoded"
+	mpType, mpBody := multipartBody(t, map[string]string{"chat_id": "-1001234567890", "caption": "Негрони"})
+	noChatType, noChatBody := multipartBody(t, map[string]string{"caption": "Негрони"})
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        int64
+	}{
+		{"form, личный чат", form, "chat_id=42&text=hi", 42},
+		{"form, группа", form, "text=hi&chat_id=-100500", -100500},
+		{"form с charset", form + "; charset=utf-8", "chat_id=7", 7},
+		{"form без chat_id", form, "callback_query_id=1&text=ok", 0},
+		{"form, @username канала", form, "chat_id=%40channel", 0},
+		{"multipart", mpType, string(mpBody), -1001234567890},
+		{"multipart без chat_id", noChatType, string(noChatBody), 0},
+		{"JSON не разбираем", "application/json", `{"chat_id": 42}`, 0},
+		{"пустой Content-Type", "", "chat_id=42", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requestChatID(tt.contentType, []byte(tt.body)); got != tt.want {
+				t.Errorf("requestChatID() = %d; want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBlockedError(t *testing.T) {
+	tests := []struct {
+		description string
+		want        bool
+	}{
+		{"Forbidden: bot was blocked by the user", true},
+		{"Forbidden: user is deactivated", true},
+		{"Bad Request: chat not found", true},
+		{"Forbidden: bot can't initiate conversation with a user", false},
+		{"Forbidden: bot was kicked from the group chat", false},
+		{"Bad Request: message is too long", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isBlockedError(tt.description); got != tt.want {
+			t.Errorf("isBlockedError(%q) = %v; want %v", tt.description, got, tt.want)
+		}
+	}
+}
+
+func TestIsPermanentSendError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"400", &tgbotapi.Error{Code: 400, Message: "Bad Request: chat not found"}, true},
+		{"403", &tgbotapi.Error{Code: 403, Message: "Forbidden: bot was blocked by the user"}, true},
+		{"обёрнутая 403", fmt.Errorf("send: %w", &tgbotapi.Error{Code: 403}), true},
+		{"429", &tgbotapi.Error{Code: 429, Message: "Too Many Requests"}, false},
+		{"502", &tgbotapi.Error{Code: 502, Message: "Bad Gateway"}, false},
+		{"сеть", errors.New("dial tcp: i/o timeout"), false},
+		{"остановка", errSenderStopped, false},
+	}
+	for _, tt := range tests {
+		if got := isPermanentSendError(tt.err); got != tt.want {
+			t.Errorf("isPermanentSendError(%s) = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+}
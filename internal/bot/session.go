@@ -0,0 +1,73 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+)
+
+// session — состояние диалога с пользователем (живёт в памяти процесса)
+type session struct {
+	lang i18n.Lang // язык интерфейса, обновляется TrackUser на каждом апдейте
+
+	lastResults []int          // ID коктейлей из последнего поиска
+	shop        *shoppingState // текущий сбор списка покупок
+
+	randomFilter db.RandomFilter // фильтр последнего /random
+	randomShown  []int           // что уже выпадало в /random, чтобы не повторяться
+
+	pendingNote int // ID коктейля, к которому ждём текст заметки
+}
+
+var sessions = struct {
+	sync.Mutex
+	m map[int64]*session
+}{m: make(map[int64]*session)}
+
+// withSession — выполняет fn над сессией пользователя под блокировкой
+func withSession(userID int64, fn func(s *session)) {
+	sessions.Lock()
+	defer sessions.Unlock()
+
+	s, ok := sessions.m[userID]
+	if !ok {
+		s = &session{}
+		sessions.m[userID] = s
+	}
+	fn(s)
+}
+
+// rememberResults — запоминает ID найденных коктейлей для последующих команд
+func rememberResults(userID int64, cocktails []db.Cocktail) {
+	ids := make([]int, 0, len(cocktails))
+	for _, c := range cocktails {
+		ids = append(ids, c.ID)
+	}
+	withSession(userID, func(s *session) { s.lastResults = ids })
+}
+
+// langOf — язык интерфейса пользователя из сессии
+func langOf(userID int64) i18n.Lang {
+	var lang i18n.Lang
+	withSession(userID, func(s *session) { lang = s.lang })
+	if lang == "" {
+		return i18n.Default
+	}
+	return lang
+}
+
+// loadLang — язык пользователя из базы для сообщений вне апдейтов (планировщик);
+// запоминает его в сессии, чтобы карточки и кнопки были на том же языке
+func loadLang(ctx context.Context, database *sql.DB, userID int64) i18n.Lang {
+	code, err := db.GetUserLanguage(ctx, database, userID)
+	if err != nil {
+		log.Printf("⚠️ Ошибка получения языка пользователя %d: %v", userID, err)
+	}
+	lang := i18n.Resolve(code)
+	withSession(userID, func(s *session) { s.lang = lang })
+	return lang
+}
@@ -0,0 +1,237 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/db"
+	"github.com/RZ-ru/Inshakerov_bot/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	maxShoppingCandidates = 20 // сколько коктейлей предлагать на выбор
+	maxShoppingServings   = 50
+)
+
+// shoppingState — выбор коктейлей и отметки "уже есть" для /shopping
+type shoppingState struct {
+	candidates []db.Cocktail
+	selected   map[int]bool
+	servings   int
+	items      []db.ShoppingItem // сводный список без учёта имеющегося
+	have       map[int]bool
+}
+
+// HandleShopping — /shopping [порций]: выбор коктейлей из избранного и последнего поиска
+func HandleShopping(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	lang := langOf(userID)
+
+	servings := 1
+	if arg := strings.TrimSpace(update.Message.CommandArguments()); arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 || n > maxShoppingServings {
+			send(bot, chatID, i18n.T(lang, "shop.bad_servings", maxShoppingServings))
+			return
+		}
+		servings = n
+	}
+
+	candidates, err := shoppingCandidates(ctx, database, userID)
+	if err != nil {
+		log.Println("Ошибка подбора коктейлей для списка покупок:", err)
+		send(bot, chatID, i18n.T(lang, "err.db"))
+		return
+	}
+	if len(candidates) == 0 {
+		send(bot, chatID, i18n.T(lang, "shop.no_candidates"))
+		return
+	}
+	localize(ctx, database, lang, candidates)
+
+	state := &shoppingState{
+		candidates: candidates,
+		selected:   make(map[int]bool),
+		servings:   servings,
+		have:       make(map[int]bool),
+	}
+	withSession(userID, func(s *session) { s.shop = state })
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "shop.select"))
+	msg.ReplyMarkup = ShoppingSelectKeyboard(state.candidates, state.selected, state.servings, lang)
+	bot.Send(msg)
+}
+
+// shoppingCandidates — избранное плюс результаты последнего поиска, без повторов
+func shoppingCandidates(ctx context.Context, database *sql.DB, userID int64) ([]db.Cocktail, error) {
+	favorites, err := db.GetFavorites(ctx, database, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastIDs []int
+	withSession(userID, func(s *session) { lastIDs = append(lastIDs, s.lastResults...) })
+	found, err := db.GetCocktailsByIDs(ctx, database, lastIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool)
+	var result []db.Cocktail
+	for _, c := range append(favorites, found...) {
+		if seen[c.ID] || len(result) >= maxShoppingCandidates {
+			continue
+		}
+		seen[c.ID] = true
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+// HandleShoppingCallback — кнопки shop_*: выбор, порции, "уже есть", итог
+func HandleShoppingCallback(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update, database *sql.DB) {
+	cq := update.CallbackQuery
+	chatID := cq.Message.Chat.ID
+	messageID := cq.Message.MessageID
+	userID := cq.From.ID
+	lang := langOf(userID)
+
+	bot.Request(tgbotapi.NewCallback(cq.ID, ""))
+
+	var state *shoppingState
+	withSession(userID, func(s *session) { state = s.shop })
+	if state == nil {
+		send(bot, chatID, i18n.T(lang, "shop.expired"))
+		return
+	}
+
+	action := strings.TrimPrefix(cq.Data, "shop_")
+	switch {
+	case strings.HasPrefix(action, "t_"):
+		id, err := strconv.Atoi(strings.TrimPrefix(action, "t_"))
+		if err != nil {
+			return
+		}
+		withSession(userID, func(*session) { state.selected[id] = !state.selected[id] })
+		bot.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID,
+			ShoppingSelectKeyboard(state.candidates, state.selected, state.servings, lang)))
+
+	case action == "s_inc" || action == "s_dec":
+		withSession(userID, func(*session) {
+			if action == "s_inc" && state.servings < maxShoppingServings {
+				state.servings++
+			} else if action == "s_dec" && state.servings > 1 {
+				state.servings--
+			}
+		})
+		bot.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID,
+			ShoppingSelectKeyboard(state.candidates, state.selected, state.servings, lang)))
+
+	case action == "next":
+		var ids []int
+		for _, c := range state.candidates {
+			if state.selected[c.ID] {
+				ids = append(ids, c.ID)
+			}
+		}
+		if len(ids) == 0 {
+			send(bot, chatID, i18n.T(lang, "shop.select_one"))
+			return
+		}
+
+		ingredients, err := db.GetCocktailIngredients(ctx, database, ids)
+		if err != nil {
+			log.Println("Ошибка получения ингредиентов для списка покупок:", err)
+			send(bot, chatID, i18n.T(lang, "err.db"))
+			return
+		}
+		if err := db.LocalizeIngredients(ctx, database, string(lang), ingredients); err != nil {
+			log.Println("Ошибка получения переводов:", err)
+		}
+		items := db.BuildShoppingList(ingredients, state.servings, nil)
+
+		// то, что уже лежит в домашнем баре, сразу отмечаем как имеющееся
+		bar, err := db.GetBar(ctx, database, userID)
+		if err != nil {
+			log.Println("Ошибка получения бара:", err)
+		}
+		withSession(userID, func(*session) {
+			state.items = items
+			for _, g := range bar {
+				state.have[g.ID] = true
+			}
+		})
+
+		bot.Send(tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID,
+			i18n.T(lang, "shop.have"),
+			ShoppingHaveKeyboard(state.items, state.have, lang)))
+
+	case strings.HasPrefix(action, "h_"):
+		id, err := strconv.Atoi(strings.TrimPrefix(action, "h_"))
+		if err != nil {
+			return
+		}
+		withSession(userID, func(*session) { state.have[id] = !state.have[id] })
+		bot.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID,
+			ShoppingHaveKeyboard(state.items, state.have, lang)))
+
+	case action == "done":
+		sendShoppingList(bot, chatID, state, lang)
+		withSession(userID, func(s *session) { s.shop = nil })
+	}
+}
+
+// sendShoppingList — итоговый чек-лист сообщением и текстовым файлом
+func sendShoppingList(bot *tgbotapi.BotAPI, chatID int64, state *shoppingState, lang i18n.Lang) {
+	var toBuy []db.ShoppingItem
+	for _, it := range state.items {
+		if !state.have[it.GoodID] {
+			toBuy = append(toBuy, it)
+		}
+	}
+
+	if len(toBuy) == 0 {
+		send(bot, chatID, i18n.T(lang, "shop.all_have"))
+		return
+	}
+
+	var cocktails []string
+	for _, c := range state.candidates {
+		if state.selected[c.ID] {
+			cocktails = append(cocktails, c.Name)
+		}
+	}
+
+	header := i18n.N(lang, "shop.header", state.servings, strings.Join(cocktails, ", "))
+	send(bot, chatID, header+"\n\n"+formatShoppingList(toBuy, "☐ ", lang))
+
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{
+		Name:  "shopping-list.txt",
+		Bytes: []byte(header + "\n\n" + formatShoppingList(toBuy, "[ ] ", lang) + "\n"),
+	})
+	bot.Send(doc)
+}
+
+// formatShoppingList — строки вида "☐ Сок лайма — 60 мл"
+func formatShoppingList(items []db.ShoppingItem, bullet string, lang i18n.Lang) string {
+	lines := make([]string, 0, len(items))
+	for _, it := range items {
+		line := bullet + it.Name
+		switch {
+		case it.Amount > 0:
+			line += " — " + strings.TrimSpace(db.FormatAmount(it.Amount)+" "+it.Unit)
+			if it.Unknown {
+				line += " + " + i18n.T(lang, "shop.to_taste")
+			}
+		case it.Unknown:
+			line += " — " + i18n.T(lang, "shop.to_taste")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/config"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// secretHeader — заголовок, в котором Telegram присылает secret_token из setWebhook
+const secretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// AllowedUpdates — типы апдейтов, которые обрабатывает бот. Telegram запоминает
+// список, поэтому его нужно передавать и в setWebhook, и в getUpdates
+var AllowedUpdates = []string{"message", "callback_query", "inline_query"}
+
+// SetWebhook — регистрирует webhook с секретом. В tgbotapi v5.5.1 нет
+// secret_token в WebhookConfig, поэтому параметры собираем сами
+func SetWebhook(bot *tgbotapi.BotAPI, cfg *config.Config) error {
+	params := tgbotapi.Params{
+		"url":          cfg.WebhookURL,
+		"secret_token": cfg.WebhookSecret,
+	}
+	if err := params.AddInterface("allowed_updates", AllowedUpdates); err != nil {
+		return err
+	}
+
+	if cfg.WebhookSelfSig && cfg.WebhookCert != "" {
+		_, err := bot.UploadFiles("setWebhook", params, []tgbotapi.RequestFile{
+			{Name: "certificate", Data: tgbotapi.FilePath(cfg.WebhookCert)},
+		})
+		return err
+	}
+	_, err := bot.MakeRequest("setWebhook", params)
+	return err
+}
+
+// DeleteWebhook — снимает webhook, чтобы снова работал long polling
+func DeleteWebhook(bot *tgbotapi.BotAPI) error {
+	_, err := bot.Request(tgbotapi.DeleteWebhookConfig{})
+	return err
+}
+
+// WebhookHandler — принимает апдейты от Telegram. Запросы без верного секрета
+// отклоняются; ответ уходит после handle, поэтому, если handle ждёт места
+// в очереди, Telegram придерживает следующие апдейты. Ошибка handle
+// (бот останавливается) — 503, и Telegram повторит доставку позже
+func WebhookHandler(secret string, handle func(tgbotapi.Update) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		got := r.Header.Get(secretHeader)
+		if subtle.ConstantTimeCompare([]byte(got), []byte(secret)) != 1 {
+			log.Printf("⚠️ Webhook: неверный секрет от %s", r.RemoteAddr)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		var update tgbotapi.Update
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&update); err != nil {
+			log.Println("⚠️ Webhook: некорректный апдейт:", err)
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		if err := handle(update); err != nil {
+			http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// ServeWebhook — HTTP-сервер для webhook. С сертификатом и ключом — сразу HTTPS,
+// без них — обычный HTTP за обратным прокси. После отмены ctx перестаёт принимать
+// запросы и ждёт текущие не дольше shutdownTimeout
+func ServeWebhook(ctx context.Context, cfg *config.Config, handler http.Handler, shutdownTimeout time.Duration) error {
+	mux := http.NewServeMux()
+	mux.Handle("/", handler)
+
+	srv := &http.Server{Addr: cfg.WebhookListen, Handler: mux}
+	errc := make(chan error, 1)
+	go func() {
+		log.Printf("🌐 Webhook слушает %s", cfg.WebhookListen)
+		if cfg.WebhookCert != "" && cfg.WebhookKey != "" {
+			errc <- srv.ListenAndServeTLS(cfg.WebhookCert, cfg.WebhookKey)
+		} else {
+			errc <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
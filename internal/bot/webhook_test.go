@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RZ-ru/Inshakerov_bot/internal/config"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	const secret = "s3cret"
+	const body = `{"update_id": 42, "message": {"message_id": 1, "text": "/start", "chat": {"id": 7, "type": "private"}}}`
+
+	tests := []struct {
+		name       string
+		method     string
+		secret     string
+		body       string
+		handleErr  error
+		wantStatus int
+		wantHandle bool
+	}{
+		{"верный секрет", http.MethodPost, secret, body, nil, http.StatusOK, true},
+		{"без секрета", http.MethodPost, "", body, nil, http.StatusForbidden, false},
+		{"чужой секрет", http.MethodPost, "wrong", body, nil, http.StatusForbidden, false},
+		{"секрет с лишним символом", http.MethodPost, secret + "x", body, nil, http.StatusForbidden, false},
+		{"не POST", http.MethodGet, secret, "", nil, http.StatusMethodNotAllowed, false},
+		{"битый JSON", http.MethodPost, secret, "{", nil, http.StatusBadRequest, false},
+		{"бот останавливается", http.MethodPost, secret, body, ErrPoolClosed, http.StatusServiceUnavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *tgbotapi.Update
+			handler := WebhookHandler(secret, func(u tgbotapi.Update) error {
+				got = &u
+				return tt.handleErr
+			})
+
+			req := httptest.NewRequest(tt.method, "/", strings.NewReader(tt.body))
+			if tt.secret != "" {
+				req.Header.Set(secretHeader, tt.secret)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("статус = %d; want %d", rec.Code, tt.wantStatus)
+			}
+			if (got != nil) != tt.wantHandle {
+				t.Fatalf("handle вызван = %v; want %v", got != nil, tt.wantHandle)
+			}
+			if got != nil && (got.UpdateID != 42 || got.Message == nil || got.Message.Chat.ID != 7) {
+				t.Errorf("апдейт разобран неверно: %+v", got)
+			}
+		})
+	}
+}
+
+func TestServeWebhookShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeWebhook(ctx, &config.Config{WebhookListen: "127.0.0.1:0"}, http.NotFoundHandler(), time.Second)
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("ServeWebhook() после отмены = %v; want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeWebhook() не остановился после отмены ctx")
+	}
+}
+
+func TestServeWebhookListenError(t *testing.T) {
+	err := ServeWebhook(context.Background(), &config.Config{WebhookListen: "127.0.0.1:-1"}, http.NotFoundHandler(), time.Second)
+	if err == nil {
+		t.Error("ServeWebhook() с неверным адресом = nil; want ошибку")
+	}
+}
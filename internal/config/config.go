@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -10,18 +12,131 @@ import (
 type Config struct {
 	BotToken string
 	DBUrl    string
+	AdminIDs []int64 // Telegram ID администраторов (ADMIN_IDS через запятую)
+
+	SuggestThreshold float64 // минимальное сходство для подсказок ингредиентов (SUGGEST_THRESHOLD)
+	SuggestLimit     int     // сколько подсказок показывать (SUGGEST_LIMIT)
+
+	Workers     int // сколько апдейтов обрабатывать параллельно (WORKERS)
+	WorkerQueue int // размер очереди каждого воркера (WORKER_QUEUE)
+
+	APIEndpoint string // адрес Bot API (TELEGRAM_API_ENDPOINT), например фейковый сервер для локальной проверки
+
+	Mode           string // способ получения апдейтов: ModePolling или ModeWebhook (BOT_MODE)
+	WebhookURL     string // публичный адрес, который регистрируется в Telegram (WEBHOOK_URL)
+	WebhookListen  string // адрес HTTP-сервера (WEBHOOK_LISTEN)
+	WebhookSecret  string // секрет для заголовка X-Telegram-Bot-Api-Secret-Token (WEBHOOK_SECRET)
+	WebhookCert    string // сертификат для HTTPS без прокси (WEBHOOK_CERT), пусто — обычный HTTP
+	WebhookKey     string // ключ сертификата (WEBHOOK_KEY)
+	WebhookSelfSig bool   // сертификат самоподписанный — отправить его в setWebhook (WEBHOOK_SELF_SIGNED)
 }
 
+// Способы получения апдейтов
+const (
+	ModePolling = "polling"
+	ModeWebhook = "webhook"
+)
+
 func Load() *Config {
 	_ = godotenv.Load() // загружаем .env, если есть
 	cfg := &Config{
 		BotToken: os.Getenv("BOT_TOKEN"),
 		DBUrl:    os.Getenv("DB_URL"),
+		AdminIDs: parseIDs(os.Getenv("ADMIN_IDS")),
+
+		SuggestThreshold: parseFloat("SUGGEST_THRESHOLD", 0.3),
+		SuggestLimit:     parseInt("SUGGEST_LIMIT", 5),
+
+		Workers:     parseInt("WORKERS", 8),
+		WorkerQueue: parseInt("WORKER_QUEUE", 64),
+
+		APIEndpoint: parseString("TELEGRAM_API_ENDPOINT", ""),
+
+		Mode:           strings.ToLower(parseString("BOT_MODE", ModePolling)),
+		WebhookURL:     parseString("WEBHOOK_URL", ""),
+		WebhookListen:  parseString("WEBHOOK_LISTEN", ":8080"),
+		WebhookSecret:  parseString("WEBHOOK_SECRET", ""),
+		WebhookCert:    parseString("WEBHOOK_CERT", ""),
+		WebhookKey:     parseString("WEBHOOK_KEY", ""),
+		WebhookSelfSig: parseString("WEBHOOK_SELF_SIGNED", "") == "true",
 	}
 
 	if cfg.BotToken == "" || cfg.DBUrl == "" {
 		log.Fatal("BOT_TOKEN or DB_URL not set in environment")
 	}
+	switch cfg.Mode {
+	case ModePolling:
+	case ModeWebhook:
+		if cfg.WebhookURL == "" || cfg.WebhookSecret == "" {
+			log.Fatal("BOT_MODE=webhook requires WEBHOOK_URL and WEBHOOK_SECRET")
+		}
+	default:
+		log.Fatalf("unknown BOT_MODE %q: expected %s or %s", cfg.Mode, ModePolling, ModeWebhook)
+	}
 
 	return cfg
 }
+
+// IsAdmin — входит ли пользователь в ADMIN_IDS
+func (c *Config) IsAdmin(userID int64) bool {
+	for _, id := range c.AdminIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIDs — разбирает список ID вида "123,456"
+func parseIDs(s string) []int64 {
+	var ids []int64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			log.Printf("⚠️ Некорректный ID администратора %q: %v", part, err)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// parseString — строка из переменной окружения или значение по умолчанию
+func parseString(key, def string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return def
+}
+
+// parseInt — целое из переменной окружения или значение по умолчанию
+func parseInt(key string, def int) int {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("⚠️ Некорректное значение %s=%q, используем %d", key, v, def)
+		return def
+	}
+	return n
+}
+
+// parseFloat — число из переменной окружения или значение по умолчанию
+func parseFloat(key string, def float64) float64 {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		log.Printf("⚠️ Некорректное значение %s=%q, используем %v", key, v, def)
+		return def
+	}
+	return f
+}
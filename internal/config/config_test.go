@@ -0,0 +1,27 @@
+package config
+
+import "testing"
+
+func TestParseNumbers(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantInt   int
+		wantFloat float64
+	}{
+		{"", 5, 0.3},
+		{"  ", 5, 0.3},
+		{"8", 8, 8},
+		{" 2 ", 2, 2},
+		{"0.45", 5, 0.45},
+		{"много", 5, 0.3},
+	}
+	for _, tt := range tests {
+		t.Setenv("SUGGEST_TEST", tt.value)
+		if got := parseInt("SUGGEST_TEST", 5); got != tt.wantInt {
+			t.Errorf("parseInt(%q) = %d; want %d", tt.value, got, tt.wantInt)
+		}
+		if got := parseFloat("SUGGEST_TEST", 0.3); got != tt.wantFloat {
+			t.Errorf("parseFloat(%q) = %v; want %v", tt.value, got, tt.wantFloat)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// GetStats — количество записей в основных таблицах
+func GetStats(ctx context.Context, db *sql.DB) (Stats, error) {
+	var s Stats
+	err := db.QueryRowContext(ctx, `
+		SELECT (SELECT COUNT(*) FROM cocktails),
+		       (SELECT COUNT(DISTINCT cocktail_id) FROM cocktail_translations),
+		       (SELECT COUNT(*) FROM goods WHERE NOT is_category),
+		       (SELECT COUNT(*) FROM good_aliases),
+		       (SELECT COUNT(*) FROM users),
+		       (SELECT COUNT(*) FROM users WHERE blocked),
+		       (SELECT COUNT(*) FROM favorites);
+	`).Scan(&s.Cocktails, &s.Translated, &s.Goods, &s.Aliases, &s.Users, &s.BlockedUsers, &s.Favorites)
+	return s, err
+}
+
+// LogAdminAction — записать действие администратора в журнал
+func LogAdminAction(ctx context.Context, db *sql.DB, adminID int64, action, args string) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO admin_audit (admin_id, action, args)
+		VALUES ($1, $2, $3);
+	`, adminID, action, args)
+	return err
+}
+
+// GetAdminActions — последние записи журнала, новые первыми
+func GetAdminActions(ctx context.Context, db *sql.DB, limit int) ([]AdminAction, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, admin_id, action, args, created_at
+		FROM admin_audit
+		ORDER BY id DESC
+		LIMIT $1;
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []AdminAction
+	for rows.Next() {
+		var a AdminAction
+		if err := rows.Scan(&a.ID, &a.AdminID, &a.Action, &a.Args, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// defaultAliases — встроенный словарь: альтернативное написание → название в goods.
+// Синонимы для ингредиентов, которых ещё нет в базе, просто пропускаются
+var defaultAliases = map[string]string{
+	"vodka":        "Водка",
+	"водочка":      "Водка",
+	"gin":          "Джин",
+	"rum":          "Белый ром",
+	"tequila":      "Текила серебряная",
+	"lime":         "Лайм",
+	"lemon":        "Лимон",
+	"mint":         "Мята",
+	"лаймовый сок": "Сок лайма",
+	"lime juice":   "Сок лайма",
+	"лимонный сок": "Сок лимона",
+	"lemon juice":  "Сок лимона",
+	"сироп":        "Сахарный сироп",
+	"simple syrup": "Сахарный сироп",
+	"лёд":          "Лед в кубиках",
+	"ice":          "Лед в кубиках",
+	"тоник":        "Тоник",
+	"tonic":        "Тоник",
+	"содовая":      "Содовая",
+	"soda":         "Содовая",
+	"triple sec":   "Трипл сек",
+	"cointreau":    "Трипл сек",
+	"апероль":      "Аперитив биттер",
+	"angostura":    "Ангостура биттер",
+	"ангостура":    "Ангостура биттер",
+	"prosecco":     "Просекко",
+	"игристое":     "Просекко",
+	"whisky":       "Шотландский виски",
+	"whiskey":      "Шотландский виски",
+	"бурбон":       "Бурбон",
+	"bourbon":      "Бурбон",
+}
+
+// NormalizeAlias — приводит написание к виду, в котором хранятся синонимы.
+// Названия в goods хранятся как есть, поэтому в запросах их приводят так же:
+// replace(LOWER(name), 'ё', 'е')
+func NormalizeAlias(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.ReplaceAll(s, "ё", "е")
+}
+
+// SeedAliases — добавляет встроенные синонимы для ингредиентов, которые уже есть в goods.
+// Безопасно вызывать повторно, например после очередного парсинга
+func SeedAliases(ctx context.Context, db *sql.DB) error {
+	for alias, name := range defaultAliases {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO good_aliases (alias, good_id)
+			SELECT $1, id FROM goods WHERE replace(LOWER(name), 'ё', 'е') = $2
+			ON CONFLICT (alias) DO NOTHING;
+		`, NormalizeAlias(alias), NormalizeAlias(name))
+		if err != nil {
+			return fmt.Errorf("синоним %s: %w", alias, err)
+		}
+	}
+	return nil
+}
+
+// AddAlias — связывает альтернативное написание с ингредиентом из goods
+func AddAlias(ctx context.Context, db *sql.DB, alias, goodName string) (Good, error) {
+	good, ok, err := ResolveGood(ctx, db, goodName)
+	if err != nil {
+		return good, err
+	}
+	if !ok {
+		return good, fmt.Errorf("ингредиент %q не найден", goodName)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO good_aliases (alias, good_id)
+		VALUES ($1, $2)
+		ON CONFLICT (alias) DO UPDATE SET good_id = EXCLUDED.good_id;
+	`, NormalizeAlias(alias), good.ID)
+	if err == nil {
+		log.Printf("🔤 Синоним %q → %s", alias, good.Name)
+	}
+	return good, err
+}
+
+// ResolveGood — точное совпадение по названию ингредиента или по синониму.
+// Служебные узлы иерархии тоже распознаются (g.IsCategory): "цитрусовые"
+// в поиске раскрываются до лайма и лимона
+func ResolveGood(ctx context.Context, db *sql.DB, text string) (Good, bool, error) {
+	var g Good
+	err := db.QueryRowContext(ctx, `
+		SELECT id, name, is_category FROM (
+			SELECT id, name, is_category, 0 AS prio FROM goods
+			WHERE replace(LOWER(name), 'ё', 'е') = $1
+			UNION ALL
+			SELECT g.id, g.name, g.is_category, 1 FROM good_aliases a JOIN goods g ON g.id = a.good_id
+			WHERE a.alias = $1
+			UNION ALL
+			SELECT g.id, g.name, g.is_category, 2 FROM good_translations t JOIN goods g ON g.id = t.good_id
+			WHERE replace(LOWER(t.name), 'ё', 'е') = $1
+		) s
+		ORDER BY prio
+		LIMIT 1;
+	`, NormalizeAlias(text)).Scan(&g.ID, &g.Name, &g.IsCategory)
+	if err == sql.ErrNoRows {
+		return g, false, nil
+	}
+	if err != nil {
+		return g, false, err
+	}
+	return g, true, nil
+}
@@ -0,0 +1,37 @@
+package db
+
+import "testing"
+
+func TestNormalizeAlias(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Лаймовый сок", "лаймовый сок"},
+		{"  Lime Juice ", "lime juice"},
+		{"Лёд", "лед"},
+		{"ЛЁД В КУБИКАХ", "лед в кубиках"},
+		{"ликёр", "ликер"},
+		{"лед", "лед"},
+		{"Cointreau", "cointreau"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeAlias(tt.in); got != tt.want {
+			t.Errorf("NormalizeAlias(%q) = %q; want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// встроенный словарь хранится в нормализованном виде при загрузке,
+// поэтому синонимы, совпадающие после нормализации, перезаписали бы друг друга
+func TestDefaultAliasesUnique(t *testing.T) {
+	seen := make(map[string]string)
+	for alias, name := range defaultAliases {
+		key := NormalizeAlias(alias)
+		if other, ok := seen[key]; ok && other != name {
+			t.Errorf("синоним %q ведёт и к %q, и к %q", key, other, name)
+		}
+		seen[key] = name
+	}
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// FindGood — ищет ингредиент по названию: сначала точное совпадение
+// (в том числе по синониму), затем самое похожее. ok=false, если ничего нет
+func FindGood(ctx context.Context, db *sql.DB, name string) (Good, bool, error) {
+	g, ok, err := ResolveGood(ctx, db, name)
+	if err != nil || ok {
+		return g, ok, err
+	}
+
+	matches, err := SuggestGoods(ctx, db, name, DefaultSuggestThreshold, 1)
+	if err != nil || len(matches) == 0 {
+		return g, false, err
+	}
+	return matches[0].Good, true, nil
+}
+
+// AddBarItem — добавить ингредиент в домашний бар
+func AddBarItem(ctx context.Context, db *sql.DB, userID int64, goodID int) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO bar_items (user_id, good_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, good_id) DO NOTHING;
+	`, userID, goodID)
+	return err
+}
+
+// RemoveBarItem — убрать ингредиент из домашнего бара
+func RemoveBarItem(ctx context.Context, db *sql.DB, userID int64, goodID int) error {
+	_, err := db.ExecContext(ctx, `
+		DELETE FROM bar_items
+		WHERE user_id = $1 AND good_id = $2;
+	`, userID, goodID)
+	return err
+}
+
+// GetBar — содержимое домашнего бара пользователя
+func GetBar(ctx context.Context, db *sql.DB, userID int64) ([]Good, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT g.id, g.name
+		FROM bar_items b
+		JOIN goods g ON b.good_id = g.id
+		WHERE b.user_id = $1
+		ORDER BY g.name;
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Good
+	for rows.Next() {
+		var g Good
+		if err := rows.Scan(&g.ID, &g.Name); err != nil {
+			return nil, err
+		}
+		result = append(result, g)
+	}
+	return result, nil
+}
+
+// GetMakeableCocktails — коктейли, которые можно приготовить из домашнего бара,
+// и те, где не хватает не более maxMissing ингредиентов. Сортировка: сначала
+// те, где недостаёт меньше всего. Игнорируемые коктейли пропускаются.
+// Общий ингредиент в баре ("Ром") покрывает своих потомков ("Белый ром").
+// При withSubstitutes=true ингредиент считается имеющимся, если в баре есть его замена
+func GetMakeableCocktails(ctx context.Context, db *sql.DB, userID int64, maxMissing, limit int, withSubstitutes bool) ([]MakeableCocktail, error) {
+	return queryMakeable(ctx, db, `SELECT good_id AS id FROM bar_items WHERE user_id = $1`,
+		userID, userID, maxMissing, limit, withSubstitutes)
+}
+
+// queryMakeable — подбор коктейлей по набору ингредиентов. ownedSQL выбирает
+// id имеющихся ингредиентов по ownerID ($1), ignoredBy — чьи игнорируемые
+// коктейли пропускать (0 — ничьи)
+func queryMakeable(ctx context.Context, db *sql.DB, ownedSQL string, ownerID, ignoredBy int64, maxMissing, limit int, withSubstitutes bool) ([]MakeableCocktail, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		WITH RECURSIVE owned AS (
+			%s
+			UNION
+			SELECT g.id FROM owned JOIN goods g ON g.parent_id = owned.id
+		),
+		covered AS (
+			SELECT id FROM owned
+			UNION
+			SELECT s.good_id FROM good_substitutions s JOIN owned o ON o.id = s.substitute_id
+			WHERE $4
+		)
+		SELECT c.id, c.name, c.url, c.image_url, c.instructions,
+		       COALESCE(array_agg(DISTINCT g.name) FILTER (WHERE b.id IS NULL), '{}') AS missing
+		FROM cocktails c
+		JOIN cocktail_ingredients ci ON c.id = ci.cocktail_id
+		JOIN goods g ON ci.good_id = g.id
+		LEFT JOIN covered b ON b.id = ci.good_id
+		WHERE NOT EXISTS (
+			SELECT 1 FROM ignored i WHERE i.user_id = $5 AND i.cocktail_id = c.id
+		)
+		GROUP BY c.id
+		HAVING COUNT(DISTINCT b.id) > 0
+		   AND COUNT(DISTINCT ci.good_id) - COUNT(DISTINCT b.id) <= $2
+		ORDER BY COUNT(DISTINCT ci.good_id) - COUNT(DISTINCT b.id),
+		         COUNT(DISTINCT b.id) DESC,
+		         c.name
+		LIMIT $3;
+	`, ownedSQL), ownerID, maxMissing, limit, withSubstitutes, ignoredBy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []MakeableCocktail
+	for rows.Next() {
+		var m MakeableCocktail
+		var missing pq.StringArray
+		if err := rows.Scan(&m.ID, &m.Name, &m.URL, &m.ImageURL, &m.Instructions, &missing); err != nil {
+			return nil, err
+		}
+		m.Missing = missing
+		result = append(result, m)
+	}
+	return result, nil
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrBroadcastNotDraft — рассылка уже запущена или отменена
+var ErrBroadcastNotDraft = errors.New("рассылка уже запущена или отменена")
+
+// CountActiveUsers — сколько пользователей получат рассылку: писавшие боту
+// в личку и не заблокировавшие его. Знакомым только по группам бот написать не может
+func CountActiveUsers(ctx context.Context, db *sql.DB) (int, error) {
+	var n int
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE started AND NOT blocked;`).Scan(&n)
+	return n, err
+}
+
+// CreateBroadcast — черновик рассылки
+func CreateBroadcast(ctx context.Context, db *sql.DB, adminID, chatID int64, text string) (int, error) {
+	var id int
+	err := db.QueryRowContext(ctx, `
+		INSERT INTO broadcasts (admin_id, chat_id, text)
+		VALUES ($1, $2, $3)
+		RETURNING id;
+	`, adminID, chatID, text).Scan(&id)
+	return id, err
+}
+
+// GetBroadcast — рассылка по ID
+func GetBroadcast(ctx context.Context, db *sql.DB, id int) (Broadcast, error) {
+	var b Broadcast
+	err := db.QueryRowContext(ctx, `
+		SELECT id, admin_id, chat_id, text, status, delivered, failed, created_at
+		FROM broadcasts
+		WHERE id = $1;
+	`, id).Scan(&b.ID, &b.AdminID, &b.ChatID, &b.Text, &b.Status, &b.Delivered, &b.Failed, &b.CreatedAt)
+	return b, err
+}
+
+// StartBroadcast — переводит черновик в отправку и фиксирует получателей:
+// тех же, что считает CountActiveUsers. Возвращает их число
+func StartBroadcast(ctx context.Context, db *sql.DB, id int) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE broadcasts SET status = 'sending', started_at = NOW()
+		WHERE id = $1 AND status = 'draft';
+	`, id)
+	if err != nil {
+		return 0, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return 0, ErrBroadcastNotDraft
+	}
+
+	res, err = tx.ExecContext(ctx, `
+		INSERT INTO broadcast_recipients (broadcast_id, user_id)
+		SELECT $1, id FROM users WHERE started AND NOT blocked
+		ON CONFLICT DO NOTHING;
+	`, id)
+	if err != nil {
+		return 0, err
+	}
+	n, _ := res.RowsAffected()
+	return int(n), tx.Commit()
+}
+
+// CancelBroadcast — отменяет черновик
+func CancelBroadcast(ctx context.Context, db *sql.DB, id int) error {
+	res, err := db.ExecContext(ctx, `
+		UPDATE broadcasts SET status = 'cancelled', finished_at = NOW()
+		WHERE id = $1 AND status = 'draft';
+	`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrBroadcastNotDraft
+	}
+	return nil
+}
+
+// GetSendingBroadcasts — незавершённые рассылки, например прерванные перезапуском
+func GetSendingBroadcasts(ctx context.Context, db *sql.DB) ([]Broadcast, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, admin_id, chat_id, text, status, delivered, failed, created_at
+		FROM broadcasts
+		WHERE status = 'sending'
+		ORDER BY id;
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Broadcast
+	for rows.Next() {
+		var b Broadcast
+		if err := rows.Scan(&b.ID, &b.AdminID, &b.ChatID, &b.Text, &b.Status, &b.Delivered, &b.Failed, &b.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, b)
+	}
+	return result, nil
+}
+
+// GetPendingRecipients — получатели, которым рассылка ещё не отправлялась
+func GetPendingRecipients(ctx context.Context, db *sql.DB, broadcastID, limit int) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT user_id FROM broadcast_recipients
+		WHERE broadcast_id = $1 AND status = 'pending'
+		ORDER BY user_id
+		LIMIT $2;
+	`, broadcastID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+// MarkRecipient — результат отправки одному получателю
+func MarkRecipient(ctx context.Context, db *sql.DB, broadcastID int, userID int64, delivered bool) error {
+	status := "sent"
+	if !delivered {
+		status = "failed"
+	}
+	_, err := db.ExecContext(ctx, `
+		UPDATE broadcast_recipients SET status = $3
+		WHERE broadcast_id = $1 AND user_id = $2;
+	`, broadcastID, userID, status)
+	return err
+}
+
+// FinishBroadcast — завершает рассылку и подсчитывает итоги
+func FinishBroadcast(ctx context.Context, db *sql.DB, id int) (Broadcast, error) {
+	_, err := db.ExecContext(ctx, `
+		UPDATE broadcasts b SET status = 'done', finished_at = NOW(),
+		       delivered = (SELECT COUNT(*) FROM broadcast_recipients WHERE broadcast_id = b.id AND status = 'sent'),
+		       failed = (SELECT COUNT(*) FROM broadcast_recipients WHERE broadcast_id = b.id AND status = 'failed')
+		WHERE id = $1;
+	`, id)
+	if err != nil {
+		return Broadcast{}, err
+	}
+	return GetBroadcast(ctx, db, id)
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// SubscribeDaily — оформить или изменить подписку на коктейль дня
+func SubscribeDaily(ctx context.Context, db *sql.DB, s DailySubscription) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO daily_subscriptions (user_id, chat_id, send_minute, timezone)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		    SET chat_id = EXCLUDED.chat_id,
+		        send_minute = EXCLUDED.send_minute,
+		        timezone = EXCLUDED.timezone;
+	`, s.UserID, s.ChatID, s.SendMinute, s.Timezone)
+	return err
+}
+
+// UnsubscribeDaily — отменить подписку на коктейль дня
+func UnsubscribeDaily(ctx context.Context, db *sql.DB, userID int64) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM daily_subscriptions WHERE user_id = $1;`, userID)
+	return err
+}
+
+// GetDailySubscription — подписка пользователя; ok=false, если её нет
+func GetDailySubscription(ctx context.Context, db *sql.DB, userID int64) (DailySubscription, bool, error) {
+	var s DailySubscription
+	var last sql.NullTime
+	err := db.QueryRowContext(ctx, `
+		SELECT user_id, chat_id, send_minute, timezone, last_sent_on
+		FROM daily_subscriptions
+		WHERE user_id = $1;
+	`, userID).Scan(&s.UserID, &s.ChatID, &s.SendMinute, &s.Timezone, &last)
+	if err == sql.ErrNoRows {
+		return s, false, nil
+	}
+	if err != nil {
+		return s, false, err
+	}
+	s.LastSentOn = last.Time
+	return s, true, nil
+}
+
+// GetDailySubscriptions — подписки пользователей, не заблокировавших бота
+// (их мало, отбор по времени делает планировщик)
+func GetDailySubscriptions(ctx context.Context, db *sql.DB) ([]DailySubscription, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT s.user_id, s.chat_id, s.send_minute, s.timezone, s.last_sent_on
+		FROM daily_subscriptions s
+		JOIN users u ON u.id = s.user_id
+		WHERE NOT u.blocked;
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []DailySubscription
+	for rows.Next() {
+		var s DailySubscription
+		var last sql.NullTime
+		if err := rows.Scan(&s.UserID, &s.ChatID, &s.SendMinute, &s.Timezone, &last); err != nil {
+			return nil, err
+		}
+		s.LastSentOn = last.Time
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// PickDailyCocktail — случайный коктейль, который пользователь не игнорирует и ещё
+// не получал как коктейль дня. Когда всё уже было, история начинается заново
+func PickDailyCocktail(ctx context.Context, db *sql.DB, userID int64) (Cocktail, error) {
+	query := `
+		SELECT c.id, c.name, c.url, c.image_url, c.instructions
+		FROM cocktails c
+		WHERE NOT EXISTS (SELECT 1 FROM ignored i WHERE i.user_id = $1 AND i.cocktail_id = c.id)
+		  AND NOT EXISTS (SELECT 1 FROM daily_history h WHERE h.user_id = $1 AND h.cocktail_id = c.id)
+		ORDER BY random()
+		LIMIT 1;
+	`
+
+	var c Cocktail
+	err := db.QueryRowContext(ctx, query, userID).Scan(&c.ID, &c.Name, &c.URL, &c.ImageURL, &c.Instructions)
+	if err == sql.ErrNoRows {
+		if _, err := db.ExecContext(ctx, `DELETE FROM daily_history WHERE user_id = $1;`, userID); err != nil {
+			return c, err
+		}
+		err = db.QueryRowContext(ctx, query, userID).Scan(&c.ID, &c.Name, &c.URL, &c.ImageURL, &c.Instructions)
+	}
+	return c, err
+}
+
+// MarkDailySent — запоминает отправленный коктейль и местную дату отправки
+func MarkDailySent(ctx context.Context, db *sql.DB, userID int64, cocktailID int, localDate time.Time) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO daily_history (user_id, cocktail_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, cocktail_id) DO UPDATE SET sent_at = NOW();
+	`, userID, cocktailID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE daily_subscriptions SET last_sent_on = $2 WHERE user_id = $1;
+	`, userID, localDate.Format("2006-01-02")); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// FullTextSearch — полнотекстовый поиск по названиям, ингредиентам и способу
+// приготовления с учётом русской морфологии, а также по английским переводам.
+// Запрос в синтаксисе websearch_to_tsquery: "точная фраза", -исключить, or.
+// Score — ts_rank лучшего из совпадений
+func FullTextSearch(ctx context.Context, db *sql.DB, userID int64, query string, limit int) ([]CocktailMatch, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT c.id, c.name, c.url, c.image_url, c.instructions,
+		       GREATEST(
+		           CASE WHEN c.search_vector @@ q THEN ts_rank(c.search_vector, q) ELSE 0 END,
+		           COALESCE(en.score, 0)
+		       ) AS score
+		FROM cocktails c
+		CROSS JOIN websearch_to_tsquery('russian', $1) q
+		CROSS JOIN websearch_to_tsquery('english', $1) qe
+		LEFT JOIN LATERAL (
+			SELECT MAX(ts_rank(t.search_vector, qe)) AS score
+			FROM cocktail_translations t
+			WHERE t.cocktail_id = c.id AND t.search_vector @@ qe
+		) en ON TRUE
+		WHERE (c.search_vector @@ q OR en.score IS NOT NULL)
+		  AND NOT EXISTS (
+			SELECT 1 FROM ignored i WHERE i.user_id = $2 AND i.cocktail_id = c.id
+		  )
+		ORDER BY score DESC, c.name
+		LIMIT $3;
+	`, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []CocktailMatch
+	for rows.Next() {
+		var m CocktailMatch
+		if err := rows.Scan(&m.ID, &m.Name, &m.URL, &m.ImageURL, &m.Instructions, &m.Score); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
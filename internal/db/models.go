@@ -1,5 +1,7 @@
 package db
 
+import "time"
+
 // Cocktail — основная сущность: рецепт коктейля
 type Cocktail struct {
 	ID           int
@@ -7,6 +9,8 @@ type Cocktail struct {
 	URL          string
 	ImageURL     string
 	Instructions string
+	Strength     string               // крепость: StrengthNone, StrengthLow, ...; "" — неизвестна
+	Tags         []string             // теги Inshaker: "Крепкие", "Сладкие", "Летние"
 	Ingredients  []CocktailIngredient // список связей с ингредиентами
 }
 
@@ -16,6 +20,10 @@ type Good struct {
 	Name     string
 	Category string // опционально, например: "Фрукты", "Алкоголь"
 	ImageURL string // опционально: картинка ингредиента
+	ParentID int    // родитель в иерархии (бренд → тип → семейство), 0 — корень
+	// IsCategory — служебный узел иерархии ("Цитрусовые"): годится как запрос
+	// и раскрывается до потомков, но в бар кладут только настоящие ингредиенты
+	IsCategory bool
 }
 
 // CocktailIngredient — связь между коктейлем и ингредиентом (многие-ко-многим)
@@ -27,3 +35,138 @@ type CocktailIngredient struct {
 	Amount     string // например "50"
 	Unit       string // например "мл", "г"
 }
+
+// ShoppingItem — позиция списка покупок (суммарно по всем выбранным коктейлям)
+type ShoppingItem struct {
+	GoodID  int
+	Name    string
+	Amount  float64 // суммарное количество на все порции
+	Unit    string
+	Unknown bool // в одном из рецептов количество не число ("по вкусу")
+}
+
+// MakeableCocktail — коктейль из "что можно приготовить" и чего для него не хватает
+type MakeableCocktail struct {
+	Cocktail
+	Missing []string // названия недостающих ингредиентов, пусто — можно готовить
+}
+
+// RankedCocktail — результат ранжированного поиска по ингредиентам
+type RankedCocktail struct {
+	Cocktail
+	Matched      int      // сколько ингредиентов запроса есть в рецепте
+	Requested    int      // сколько разных ингредиентов было в запросе
+	Total        int      // сколько всего ингредиентов в рецепте
+	MatchedNames []string // какие именно совпали
+	Score        float64  // итоговая оценка, больше — лучше
+}
+
+// CocktailMatch — коктейль, найденный по названию, и степень сходства (0..1)
+type CocktailMatch struct {
+	Cocktail
+	Score float64
+}
+
+// GoodMatch — ингредиент, похожий на введённый текст, и степень сходства (0..1)
+type GoodMatch struct {
+	Good
+	Score   float64
+	Recipes int // в скольких рецептах встречается
+}
+
+// Substitute — чем можно заменить ингредиент
+type Substitute struct {
+	Good
+	Score   float64 // 1 для курируемых, иначе сходство по статистике рецептов
+	Curated bool    // из курируемого списка, а не из статистики
+}
+
+// RandomFilter — ограничения для случайного коктейля; пустые поля не учитываются
+type RandomFilter struct {
+	GoodID   int    // должен содержать ингредиент (или его потомка в иерархии)
+	Tag      string // должен иметь тег
+	Strength string // крепость
+}
+
+// DailySubscription — подписка на коктейль дня
+type DailySubscription struct {
+	UserID     int64
+	ChatID     int64
+	SendMinute int       // минуты от полуночи по местному времени
+	Timezone   string    // IANA, например "Europe/Moscow"
+	LastSentOn time.Time // дата последней отправки (местная), нулевая — ещё не отправляли
+}
+
+// Recommendation — рекомендованный коктейль и объяснение, откуда он взялся
+type Recommendation struct {
+	Cocktail
+	Score     float64
+	Because   string // название избранного коктейля, на который он больше всего похож
+	BecauseID int    // его ID — для перевода названия; 0, если Because пусто
+}
+
+// RatedCocktail — коктейль с личной оценкой и заметкой пользователя
+type RatedCocktail struct {
+	Cocktail
+	Rating int    // 1..5, 0 — не оценён
+	Note   string // личная заметка
+}
+
+// User — пользователь бота
+type User struct {
+	ID           int64 // Telegram user ID
+	Username     string
+	FirstName    string
+	LanguageCode string // из Telegram, например "ru", "en"
+	Language     string // выбран через /lang; пусто — берётся LanguageCode
+	FirstSeen    time.Time
+	LastActive   time.Time
+	Blocked      bool // заблокировал бота — рассылки ему не отправляются
+}
+
+// Stats — сводка по базе для /admin stats
+type Stats struct {
+	Cocktails    int
+	Translated   int // коктейлей с переводом хотя бы на один язык
+	Goods        int
+	Aliases      int
+	Users        int
+	BlockedUsers int
+	Favorites    int
+}
+
+// AdminAction — запись журнала действий администратора
+type AdminAction struct {
+	ID        int
+	AdminID   int64
+	Action    string
+	Args      string
+	CreatedAt time.Time
+}
+
+// Статусы рассылки
+const (
+	BroadcastDraft     = "draft"
+	BroadcastSending   = "sending"
+	BroadcastDone      = "done"
+	BroadcastCancelled = "cancelled"
+)
+
+// Broadcast — рассылка объявления всем активным пользователям
+type Broadcast struct {
+	ID        int
+	AdminID   int64
+	ChatID    int64 // куда прислать отчёт
+	Text      string
+	Status    string // BroadcastDraft, BroadcastSending, ...
+	Delivered int
+	Failed    int
+	CreatedAt time.Time
+}
+
+// PartyItem — ингредиент в общем баре группы и кто его принесёт
+type PartyItem struct {
+	Good
+	UserID   int64
+	UserName string // имя участника для списка
+}
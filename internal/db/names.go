@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// likeEscaper — экранирует спецсимволы LIKE во вводе пользователя
+// (в PostgreSQL по умолчанию экранирующий символ — обратная косая черта)
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike — текст, который в шаблоне LIKE совпадает только сам с собой
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// SearchCocktailsByName — нечёткий поиск коктейля по названию (pg_trgm),
+// в том числе по переводам названий. Точное совпадение даёт 1,
+// совпадение по началу названия — не меньше 0.9. Пустой запрос ничего не находит
+func SearchCocktailsByName(ctx context.Context, db *sql.DB, query string, limit int) ([]CocktailMatch, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT c.id, c.name, c.url, c.image_url, c.instructions, best.score
+		FROM (
+			SELECT DISTINCT ON (id) id,
+			       GREATEST(
+			           similarity(LOWER(title), LOWER($1)),
+			           word_similarity(LOWER($1), LOWER(title)) * 0.95,
+			           CASE WHEN LOWER(title) LIKE LOWER($3) || '%' THEN 0.9 ELSE 0 END
+			       ) AS score
+			FROM (
+				SELECT id, name AS title FROM cocktails
+				UNION ALL
+				SELECT cocktail_id, name FROM cocktail_translations
+			) titles
+			WHERE LOWER(title) % LOWER($1)
+			   OR LOWER($1) <% LOWER(title)
+			   OR LOWER(title) LIKE LOWER($3) || '%'
+			ORDER BY id, score DESC
+		) best
+		JOIN cocktails c ON c.id = best.id
+		ORDER BY best.score DESC, c.name
+		LIMIT $2;
+	`, query, limit, escapeLike(query))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []CocktailMatch
+	for rows.Next() {
+		var m CocktailMatch
+		if err := rows.Scan(&m.ID, &m.Name, &m.URL, &m.ImageURL, &m.Instructions, &m.Score); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// DefaultSuggestThreshold — минимальное сходство для подсказок ингредиентов по умолчанию
+const DefaultSuggestThreshold = 0.3
+
+// SuggestGoods — ингредиенты, похожие на введённый текст, от самых похожих.
+// Сравнивает с названиями, синонимами и переводами; возвращает канонические goods
+// с числом рецептов, где встречаются они или их потомки — столько найдёт поиск
+// по ингредиенту. minScore — порог сходства (0..1). Пустой запрос ничего не находит
+func SuggestGoods(ctx context.Context, db *sql.DB, query string, minScore float64, limit int) ([]GoodMatch, error) {
+	query = NormalizeAlias(query)
+	if query == "" {
+		return nil, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT best.id, best.name, best.is_category, best.score,
+		       (WITH RECURSIVE tree AS (
+		            SELECT best.id AS id
+		            UNION
+		            SELECT g.id FROM tree JOIN goods g ON g.parent_id = tree.id
+		        )
+		        SELECT COUNT(DISTINCT cocktail_id) FROM cocktail_ingredients
+		        WHERE good_id IN (SELECT id FROM tree)) AS recipes
+		FROM (
+			SELECT DISTINCT ON (id) id, name, is_category, score FROM (
+				SELECT id, name, is_category,
+				       GREATEST(
+				           similarity(replace(LOWER(name), 'ё', 'е'), $1),
+				           CASE WHEN replace(LOWER(name), 'ё', 'е') LIKE '%' || $4 || '%' THEN 0.5 ELSE 0 END
+				       ) AS score
+				FROM goods
+				UNION ALL
+				SELECT g.id, g.name, g.is_category, similarity(a.alias, $1)
+				FROM good_aliases a JOIN goods g ON g.id = a.good_id
+				UNION ALL
+				SELECT g.id, g.name, g.is_category, similarity(replace(LOWER(t.name), 'ё', 'е'), $1)
+				FROM good_translations t JOIN goods g ON g.id = t.good_id
+			) candidates
+			WHERE score >= $2
+			ORDER BY id, score DESC
+		) best
+		ORDER BY best.score DESC, recipes DESC, best.name
+		LIMIT $3;
+	`, query, minScore, limit, escapeLike(query))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []GoodMatch
+	for rows.Next() {
+		var m GoodMatch
+		if err := rows.Scan(&m.ID, &m.Name, &m.IsCategory, &m.Score, &m.Recipes); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
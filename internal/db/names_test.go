@@ -0,0 +1,22 @@
+package db
+
+import "testing"
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"негрони", "негрони"},
+		{"100%", `100\%`},
+		{"blue_lagoon", `blue\_lagoon`},
+		{`a\b`, `a\\b`},
+		{`%_\`, `\%\_\\`},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := escapeLike(tt.in); got != tt.want {
+			t.Errorf("escapeLike(%q) = %q; want %q", tt.in, got, tt.want)
+		}
+	}
+}
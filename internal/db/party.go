@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// AddPartyItem — участник группы приносит ингредиент. Если его уже кто-то
+// принёс, запись остаётся за первым
+func AddPartyItem(ctx context.Context, db *sql.DB, chatID, userID int64, goodID int) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO party_items (chat_id, good_id, user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (chat_id, good_id) DO NOTHING;
+	`, chatID, goodID, userID)
+	return err
+}
+
+// RemovePartyItem — убрать ингредиент из бара группы
+func RemovePartyItem(ctx context.Context, db *sql.DB, chatID int64, goodID int) error {
+	_, err := db.ExecContext(ctx, `
+		DELETE FROM party_items
+		WHERE chat_id = $1 AND good_id = $2;
+	`, chatID, goodID)
+	return err
+}
+
+// ClearParty — очистить бар группы, например после вечеринки
+func ClearParty(ctx context.Context, db *sql.DB, chatID int64) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM party_items WHERE chat_id = $1;`, chatID)
+	return err
+}
+
+// GetParty — бар группы с именами участников
+func GetParty(ctx context.Context, db *sql.DB, chatID int64) ([]PartyItem, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT g.id, g.name, p.user_id,
+		       COALESCE(NULLIF(u.first_name, ''), u.username, '')
+		FROM party_items p
+		JOIN goods g ON p.good_id = g.id
+		LEFT JOIN users u ON u.id = p.user_id
+		WHERE p.chat_id = $1
+		ORDER BY p.created_at, g.name;
+	`, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []PartyItem
+	for rows.Next() {
+		var p PartyItem
+		if err := rows.Scan(&p.ID, &p.Name, &p.UserID, &p.UserName); err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// GetPartyMakeableCocktails — что группа может приготовить из общего бара.
+// Правила те же, что у GetMakeableCocktails; игнорируемые коктейли личные и тут не учитываются
+func GetPartyMakeableCocktails(ctx context.Context, db *sql.DB, chatID int64, maxMissing, limit int, withSubstitutes bool) ([]MakeableCocktail, error) {
+	return queryMakeable(ctx, db, `SELECT good_id AS id FROM party_items WHERE chat_id = $1`,
+		chatID, 0, maxMissing, limit, withSubstitutes)
+}
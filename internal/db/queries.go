@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -9,10 +10,10 @@ import (
 )
 
 // SaveRecipes — сохраняет список коктейлей в базу
-func SaveRecipes(db *sql.DB, cocktails []Cocktail) error {
+func SaveRecipes(ctx context.Context, db *sql.DB, cocktails []Cocktail) error {
 	for _, cocktail := range cocktails {
 		// 1️⃣ Добавляем коктейль
-		cocktailID, err := insertCocktail(db, cocktail)
+		cocktailID, err := insertCocktail(ctx, db, cocktail)
 		if err != nil {
 			log.Printf("❌ Ошибка добавления коктейля %s: %v", cocktail.Name, err)
 			continue
@@ -20,50 +21,80 @@ func SaveRecipes(db *sql.DB, cocktails []Cocktail) error {
 
 		// 2️⃣ Добавляем ингредиенты и связи
 		for _, ing := range cocktail.Ingredients {
-			goodID, err := getOrCreateGood(db, ing.Good.Name)
+			goodID, err := getOrCreateGood(ctx, db, ing.Good.Name)
 			if err != nil {
 				log.Printf("⚠️ Ошибка при добавлении ингредиента %s: %v", ing.Good.Name, err)
 				continue
 			}
 
-			err = insertCocktailIngredient(db, cocktailID, goodID, ing.Amount, ing.Unit)
+			err = insertCocktailIngredient(ctx, db, cocktailID, goodID, ing.Amount, ing.Unit)
 			if err != nil {
 				log.Printf("⚠️ Ошибка при добавлении связи %s -> %s: %v", cocktail.Name, ing.Good.Name, err)
 			}
 		}
+
+		// 3️⃣ Добавляем теги
+		if err := saveCocktailTags(ctx, db, cocktailID, cocktail.Tags); err != nil {
+			log.Printf("⚠️ Ошибка при добавлении тегов %s: %v", cocktail.Name, err)
+		}
+
+		// 4️⃣ Обновляем текст ингредиентов для полнотекстового поиска
+		if err := refreshIngredientsText(ctx, db, cocktailID); err != nil {
+			log.Printf("⚠️ Ошибка обновления поискового текста %s: %v", cocktail.Name, err)
+		}
 	}
 
 	log.Printf("✅ Успешно сохранено %d коктейлей", len(cocktails))
+
+	// 5️⃣ Новые ингредиенты встраиваем в иерархию, синонимы и замены. На пустой
+	// базе при старте бота это было нечем сделать, поэтому повторяем после парсинга.
+	// Иерархия нужна до замен: SeedSubstitutions заодно пересчитывает статистику,
+	// а она опирается на parent_id
+	if err := SeedTaxonomy(ctx, db); err != nil {
+		log.Printf("⚠️ Ошибка построения иерархии ингредиентов: %v", err)
+	}
+	if err := SeedAliases(ctx, db); err != nil {
+		log.Printf("⚠️ Ошибка загрузки синонимов: %v", err)
+	}
+	if err := SeedSubstitutions(ctx, db); err != nil {
+		log.Printf("⚠️ Ошибка загрузки замен ингредиентов: %v", err)
+	}
+
+	// 6️⃣ Пересчитываем похожие коктейли по новым рецептам
+	if err := RefreshSimilarity(ctx, db); err != nil {
+		log.Printf("⚠️ Ошибка пересчёта похожих коктейлей: %v", err)
+	}
 	return nil
 }
 
 // insertCocktail — вставляет коктейль и возвращает его ID
-func insertCocktail(db *sql.DB, c Cocktail) (int, error) {
+func insertCocktail(ctx context.Context, db *sql.DB, c Cocktail) (int, error) {
 	var id int
-	err := db.QueryRow(`
-		INSERT INTO cocktails (name, url, image_url, instructions)
-		VALUES ($1, $2, $3, $4)
+	err := db.QueryRowContext(ctx, `
+		INSERT INTO cocktails (name, url, image_url, instructions, strength)
+		VALUES ($1, $2, $3, $4, $5)
 		ON CONFLICT (name) DO UPDATE
 		    SET url = EXCLUDED.url,
 		        image_url = EXCLUDED.image_url,
-		        instructions = EXCLUDED.instructions
+		        instructions = EXCLUDED.instructions,
+		        strength = EXCLUDED.strength
 		RETURNING id;
-	`, c.Name, c.URL, c.ImageURL, c.Instructions).Scan(&id)
+	`, c.Name, c.URL, c.ImageURL, c.Instructions, StrengthFromTags(c.Tags)).Scan(&id)
 
 	if err == sql.ErrNoRows {
 		// если обновление без RETURNING
-		err = db.QueryRow(`SELECT id FROM cocktails WHERE name = $1`, c.Name).Scan(&id)
+		err = db.QueryRowContext(ctx, `SELECT id FROM cocktails WHERE name = $1`, c.Name).Scan(&id)
 	}
 	return id, err
 }
 
 // getOrCreateGood — возвращает ID ингредиента, создавая новый при необходимости
-func getOrCreateGood(db *sql.DB, name string) (int, error) {
+func getOrCreateGood(ctx context.Context, db *sql.DB, name string) (int, error) {
 	var id int
 
-	err := db.QueryRow(`SELECT id FROM goods WHERE name = $1`, name).Scan(&id)
+	err := db.QueryRowContext(ctx, `SELECT id FROM goods WHERE name = $1`, name).Scan(&id)
 	if err == sql.ErrNoRows {
-		err = db.QueryRow(`
+		err = db.QueryRowContext(ctx, `
 			INSERT INTO goods (name) VALUES ($1)
 			ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
 			RETURNING id;
@@ -73,8 +104,8 @@ func getOrCreateGood(db *sql.DB, name string) (int, error) {
 }
 
 // insertCocktailIngredient — создаёт связь коктейль ↔ ингредиент
-func insertCocktailIngredient(db *sql.DB, cocktailID, goodID int, amount, unit string) error {
-	_, err := db.Exec(`
+func insertCocktailIngredient(ctx context.Context, db *sql.DB, cocktailID, goodID int, amount, unit string) error {
+	_, err := db.ExecContext(ctx, `
 		INSERT INTO cocktail_ingredients (cocktail_id, good_id, amount, unit)
 		VALUES ($1, $2, $3, $4)
 		ON CONFLICT DO NOTHING;
@@ -82,23 +113,46 @@ func insertCocktailIngredient(db *sql.DB, cocktailID, goodID int, amount, unit s
 	return err
 }
 
-// GetCocktailsByIngredients — поиск коктейлей по списку ингредиентов
-func GetCocktailsByIngredients(db *sql.DB, ingredients []string) ([]Cocktail, error) {
+// refreshIngredientsText — пересобирает cocktails.ingredients_text из связей
+func refreshIngredientsText(ctx context.Context, db *sql.DB, cocktailID int) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE cocktails SET ingredients_text = COALESCE((
+			SELECT string_agg(g.name, ' ' ORDER BY g.name)
+			FROM cocktail_ingredients ci JOIN goods g ON g.id = ci.good_id
+			WHERE ci.cocktail_id = $1
+		), '')
+		WHERE id = $1;
+	`, cocktailID)
+	return err
+}
+
+// GetCocktailsByIngredients — поиск коктейлей по списку ингредиентов.
+// При expand=true каждый ингредиент раскрывается до потомков в иерархии goods:
+// "Ром" засчитывается, если в рецепте есть "Белый ром" или "Золотой ром"
+func GetCocktailsByIngredients(ctx context.Context, db *sql.DB, ingredients []string, expand bool) ([]Cocktail, error) {
 	if len(ingredients) == 0 {
 		return nil, fmt.Errorf("список ингредиентов пуст")
 	}
 
 	query := `
+		WITH RECURSIVE q AS (
+			SELECT ord, name FROM unnest($1::text[]) WITH ORDINALITY AS t(name, ord)
+		),
+		tree AS (
+			SELECT q.ord, g.id FROM q JOIN goods g ON LOWER(g.name) = LOWER(q.name)
+			UNION
+			SELECT tree.ord, g.id FROM tree JOIN goods g ON g.parent_id = tree.id
+			WHERE $3
+		)
 		SELECT c.id, c.name, c.url, c.image_url, c.instructions
 		FROM cocktails c
 		JOIN cocktail_ingredients ci ON c.id = ci.cocktail_id
-		JOIN goods g ON ci.good_id = g.id
-		WHERE g.name = ANY($1)
+		JOIN tree ON ci.good_id = tree.id
 		GROUP BY c.id
-		HAVING COUNT(DISTINCT g.name) = $2;
+		HAVING COUNT(DISTINCT tree.ord) = $2;
 	`
 
-	rows, err := db.Query(query, pq.Array(ingredients), len(ingredients))
+	rows, err := db.QueryContext(ctx, query, pq.Array(ingredients), len(ingredients), expand)
 	if err != nil {
 		return nil, err
 	}
@@ -117,8 +171,8 @@ func GetCocktailsByIngredients(db *sql.DB, ingredients []string) ([]Cocktail, er
 }
 
 // AddFavorite — добавить коктейль в избранное
-func AddFavorite(db *sql.DB, userID int64, cocktailID int) error {
-	_, err := db.Exec(`
+func AddFavorite(ctx context.Context, db *sql.DB, userID int64, cocktailID int) error {
+	_, err := db.ExecContext(ctx, `
 		INSERT INTO favorites (user_id, cocktail_id)
 		VALUES ($1, $2)
 		ON CONFLICT (user_id, cocktail_id) DO NOTHING;
@@ -127,8 +181,8 @@ func AddFavorite(db *sql.DB, userID int64, cocktailID int) error {
 }
 
 // RemoveFavorite — удалить коктейль из избранного
-func RemoveFavorite(db *sql.DB, userID int64, cocktailID int) error {
-	_, err := db.Exec(`
+func RemoveFavorite(ctx context.Context, db *sql.DB, userID int64, cocktailID int) error {
+	_, err := db.ExecContext(ctx, `
 		DELETE FROM favorites
 		WHERE user_id = $1 AND cocktail_id = $2;
 	`, userID, cocktailID)
@@ -136,8 +190,8 @@ func RemoveFavorite(db *sql.DB, userID int64, cocktailID int) error {
 }
 
 // GetFavorites — получить список избранных коктейлей пользователя
-func GetFavorites(db *sql.DB, userID int64) ([]Cocktail, error) {
-	rows, err := db.Query(`
+func GetFavorites(ctx context.Context, db *sql.DB, userID int64) ([]Cocktail, error) {
+	rows, err := db.QueryContext(ctx, `
 		SELECT c.id, c.name, c.url, c.image_url, c.instructions
 		FROM cocktails c
 		JOIN favorites f ON c.id = f.cocktail_id
@@ -161,8 +215,8 @@ func GetFavorites(db *sql.DB, userID int64) ([]Cocktail, error) {
 }
 
 // AddIgnored — добавить коктейль в игнор
-func AddIgnored(db *sql.DB, userID int64, cocktailID int) error {
-	_, err := db.Exec(`
+func AddIgnored(ctx context.Context, db *sql.DB, userID int64, cocktailID int) error {
+	_, err := db.ExecContext(ctx, `
 		INSERT INTO ignored (user_id, cocktail_id)
 		VALUES ($1, $2)
 		ON CONFLICT (user_id, cocktail_id) DO NOTHING;
@@ -171,8 +225,8 @@ func AddIgnored(db *sql.DB, userID int64, cocktailID int) error {
 }
 
 // RemoveIgnored — удалить коктейль из игнора
-func RemoveIgnored(db *sql.DB, userID int64, cocktailID int) error {
-	_, err := db.Exec(`
+func RemoveIgnored(ctx context.Context, db *sql.DB, userID int64, cocktailID int) error {
+	_, err := db.ExecContext(ctx, `
 		DELETE FROM ignored
 		WHERE user_id = $1 AND cocktail_id = $2;
 	`, userID, cocktailID)
@@ -180,8 +234,8 @@ func RemoveIgnored(db *sql.DB, userID int64, cocktailID int) error {
 }
 
 // GetIgnored — получить список игнорированных коктейлей пользователя
-func GetIgnored(db *sql.DB, userID int64) ([]Cocktail, error) {
-	rows, err := db.Query(`
+func GetIgnored(ctx context.Context, db *sql.DB, userID int64) ([]Cocktail, error) {
+	rows, err := db.QueryContext(ctx, `
 		SELECT c.id, c.name, c.url, c.image_url, c.instructions
 		FROM cocktails c
 		JOIN ignored i ON c.id = i.cocktail_id
@@ -204,17 +258,23 @@ func GetIgnored(db *sql.DB, userID int64) ([]Cocktail, error) {
 	return result, nil
 }
 
-// GetCocktailsBySimilarIngredients ищет коктейли, где ингредиенты похожи по названию
-func GetCocktailsBySimilarIngredients(db *sql.DB, ingredient string) ([]Cocktail, error) {
+// GetCocktailsBySimilarIngredients ищет коктейли, где ингредиенты похожи по названию.
+// При expand=true учитываются и потомки найденных ингредиентов в иерархии goods
+func GetCocktailsBySimilarIngredients(ctx context.Context, db *sql.DB, ingredient string, expand bool) ([]Cocktail, error) {
 	query := `
+		WITH RECURSIVE tree AS (
+			SELECT id FROM goods WHERE LOWER(name) ILIKE '%' || $1 || '%'
+			UNION
+			SELECT g.id FROM tree JOIN goods g ON g.parent_id = tree.id
+			WHERE $2
+		)
 		SELECT DISTINCT c.id, c.name, c.url, c.image_url, c.instructions
 		FROM cocktails c
 		JOIN cocktail_ingredients ci ON c.id = ci.cocktail_id
-		JOIN goods g ON ci.good_id = g.id
-		WHERE LOWER(g.name) ILIKE '%' || $1 || '%';
+		WHERE ci.good_id IN (SELECT id FROM tree);
 	`
 
-	rows, err := db.Query(query, ingredient)
+	rows, err := db.QueryContext(ctx, query, escapeLike(ingredient), expand)
 	if err != nil {
 		return nil, err
 	}
@@ -231,3 +291,60 @@ func GetCocktailsBySimilarIngredients(db *sql.DB, ingredient string) ([]Cocktail
 
 	return cocktails, nil
 }
+
+// GetGood — ингредиент по ID
+func GetGood(ctx context.Context, db *sql.DB, id int) (Good, error) {
+	var g Good
+	err := db.QueryRowContext(ctx, `
+		SELECT id, name, COALESCE(parent_id, 0)
+		FROM goods
+		WHERE id = $1;
+	`, id).Scan(&g.ID, &g.Name, &g.ParentID)
+	return g, err
+}
+
+// GetCocktail — коктейль по ID вместе с ингредиентами
+func GetCocktail(ctx context.Context, db *sql.DB, id int) (Cocktail, error) {
+	var c Cocktail
+	err := db.QueryRowContext(ctx, `
+		SELECT id, name, url, image_url, instructions
+		FROM cocktails
+		WHERE id = $1;
+	`, id).Scan(&c.ID, &c.Name, &c.URL, &c.ImageURL, &c.Instructions)
+	if err != nil {
+		return c, err
+	}
+
+	c.Ingredients, err = GetCocktailIngredients(ctx, db, []int{id})
+	return c, err
+}
+
+// GetGoodDescendants — все потомки ингредиента в иерархии (бренд → тип → семейство),
+// кроме служебных узлов
+func GetGoodDescendants(ctx context.Context, db *sql.DB, goodID int) ([]Good, error) {
+	rows, err := db.QueryContext(ctx, `
+		WITH RECURSIVE tree AS (
+			SELECT id FROM goods WHERE parent_id = $1
+			UNION
+			SELECT g.id FROM tree JOIN goods g ON g.parent_id = tree.id
+		)
+		SELECT g.id, g.name, COALESCE(g.parent_id, 0)
+		FROM goods g JOIN tree ON tree.id = g.id
+		WHERE NOT g.is_category
+		ORDER BY g.name;
+	`, goodID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Good
+	for rows.Next() {
+		var g Good
+		if err := rows.Scan(&g.ID, &g.Name, &g.ParentID); err != nil {
+			return nil, err
+		}
+		result = append(result, g)
+	}
+	return result, nil
+}
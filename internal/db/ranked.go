@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Веса составляющих оценки в SearchCocktailsRanked
+const (
+	rankQueryWeight  = 0.6 // доля запроса, покрытая рецептом (с учётом важности ингредиентов)
+	rankRecipeWeight = 0.4 // доля рецепта, покрытая запросом
+)
+
+// SearchCocktailsRanked — поиск по частичному совпадению ингредиентов.
+// В отличие от GetCocktailsByIngredients не требует наличия всех ингредиентов:
+// коктейли ранжируются по числу совпадений, по доле рецепта, которую покрывает
+// запрос, и по важности ингредиентов (редкий ингредиент весит больше, чем
+// лёд или сахарный сироп, которые есть почти везде — вес как в IDF).
+// Общие ингредиенты раскрываются по иерархии: "Ром" покрывает "Белый ром".
+// Ингредиенты запроса, которых нет ни в одном рецепте, остаются в знаменателе
+// доли запроса с наибольшим весом — иначе оценка завышалась бы.
+// Requested — число разных ингредиентов запроса: синоним и каноническое
+// название одного ингредиента считаются один раз
+func SearchCocktailsRanked(ctx context.Context, db *sql.DB, userID int64, ingredients []string, limit int) ([]RankedCocktail, error) {
+	if len(ingredients) == 0 {
+		return nil, fmt.Errorf("список ингредиентов пуст")
+	}
+
+	lowered := make([]string, 0, len(ingredients))
+	for _, name := range ingredients {
+		lowered = append(lowered, strings.ToLower(strings.TrimSpace(name)))
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		WITH RECURSIVE q AS (
+			SELECT id AS root, name FROM goods WHERE LOWER(name) = ANY($1)
+		),
+		tree AS (
+			SELECT root, root AS id FROM q
+			UNION
+			SELECT tree.root, g.id FROM tree JOIN goods g ON g.parent_id = tree.id
+		),
+		usage AS (
+			SELECT tree.root, COUNT(DISTINCT ci.cocktail_id) AS cnt
+			FROM tree JOIN cocktail_ingredients ci ON ci.good_id = tree.id
+			GROUP BY tree.root
+		),
+		w AS (
+			SELECT q.root, q.name,
+			       LN(GREATEST((SELECT COUNT(*) FROM cocktails), 1)::float / GREATEST(COALESCE(u.cnt, 0), 1)) + 1 AS weight
+			FROM q LEFT JOIN usage u ON u.root = q.root
+		),
+		hits AS (
+			SELECT ct.cocktail_id,
+			       COUNT(*) AS matched,
+			       SUM(w.weight) AS weight,
+			       array_agg(w.name ORDER BY w.weight DESC) AS names
+			FROM (
+				SELECT DISTINCT ci.cocktail_id, tree.root
+				FROM cocktail_ingredients ci JOIN tree ON tree.id = ci.good_id
+			) ct
+			JOIN w ON w.root = ct.root
+			GROUP BY ct.cocktail_id
+		),
+		sizes AS (
+			SELECT cocktail_id, COUNT(DISTINCT good_id) AS total
+			FROM cocktail_ingredients
+			WHERE cocktail_id IN (SELECT cocktail_id FROM hits)
+			GROUP BY cocktail_id
+		)
+		SELECT c.id, c.name, c.url, c.image_url, c.instructions,
+		       h.matched, s.total, (SELECT COUNT(*) FROM q) AS requested, h.names,
+		       $3 * h.weight / (SELECT SUM(weight) FROM w) +
+		       $4 * LEAST(1, h.matched::float / s.total) AS score
+		FROM hits h
+		JOIN sizes s ON s.cocktail_id = h.cocktail_id
+		JOIN cocktails c ON c.id = h.cocktail_id
+		WHERE NOT EXISTS (
+			SELECT 1 FROM ignored i WHERE i.user_id = $2 AND i.cocktail_id = c.id
+		)
+		ORDER BY score DESC, h.matched DESC, c.name
+		LIMIT $5;
+	`, pq.Array(lowered), userID, rankQueryWeight, rankRecipeWeight, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []RankedCocktail
+	for rows.Next() {
+		var r RankedCocktail
+		var names pq.StringArray
+		if err := rows.Scan(&r.ID, &r.Name, &r.URL, &r.ImageURL, &r.Instructions,
+			&r.Matched, &r.Total, &r.Requested, &names, &r.Score); err != nil {
+			return nil, err
+		}
+		r.MatchedNames = names
+		result = append(result, r)
+	}
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// SetRating — поставить коктейлю оценку 1..5
+func SetRating(ctx context.Context, db *sql.DB, userID int64, cocktailID, rating int) error {
+	if rating < 1 || rating > 5 {
+		return fmt.Errorf("оценка должна быть от 1 до 5, получено %d", rating)
+	}
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO ratings (user_id, cocktail_id, rating)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, cocktail_id) DO UPDATE
+		    SET rating = EXCLUDED.rating, updated_at = NOW();
+	`, userID, cocktailID, rating)
+	return err
+}
+
+// SetNote — сохранить личную заметку; пустая строка удаляет заметку
+func SetNote(ctx context.Context, db *sql.DB, userID int64, cocktailID int, note string) error {
+	if note == "" {
+		_, err := db.ExecContext(ctx, `
+			DELETE FROM notes
+			WHERE user_id = $1 AND cocktail_id = $2;
+		`, userID, cocktailID)
+		return err
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO notes (user_id, cocktail_id, note)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, cocktail_id) DO UPDATE
+		    SET note = EXCLUDED.note, updated_at = NOW();
+	`, userID, cocktailID, note)
+	return err
+}
+
+// GetPersonal — оценка и заметка пользователя к коктейлю (нули, если их нет)
+func GetPersonal(ctx context.Context, db *sql.DB, userID int64, cocktailID int) (rating int, note string, err error) {
+	err = db.QueryRowContext(ctx, `
+		SELECT COALESCE((SELECT rating FROM ratings WHERE user_id = $1 AND cocktail_id = $2), 0),
+		       COALESCE((SELECT note FROM notes WHERE user_id = $1 AND cocktail_id = $2), '');
+	`, userID, cocktailID).Scan(&rating, &note)
+	return rating, note, err
+}
+
+// GetRatedFavorites — избранное с оценками и заметками. byRating=true сортирует
+// по оценке (неоценённые в конце), иначе — по дате добавления
+func GetRatedFavorites(ctx context.Context, db *sql.DB, userID int64, byRating bool) ([]RatedCocktail, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT c.id, c.name, c.url, c.image_url, c.instructions,
+		       COALESCE(r.rating, 0), COALESCE(n.note, '')
+		FROM cocktails c
+		JOIN favorites f ON c.id = f.cocktail_id
+		LEFT JOIN ratings r ON r.user_id = f.user_id AND r.cocktail_id = c.id
+		LEFT JOIN notes n ON n.user_id = f.user_id AND n.cocktail_id = c.id
+		WHERE f.user_id = $1
+		ORDER BY CASE WHEN $2 THEN COALESCE(r.rating, 0) END DESC NULLS LAST,
+		         f.created_at DESC;
+	`, userID, byRating)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []RatedCocktail
+	for rows.Next() {
+		var c RatedCocktail
+		if err := rows.Scan(&c.ID, &c.Name, &c.URL, &c.ImageURL, &c.Instructions, &c.Rating, &c.Note); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Параметры профиля вкуса для GetRecommendations
+const (
+	ignorePenalty = 1.5 // во сколько раз игнор весит больше избранного
+	tagWeight     = 0.5 // вес совпадения по тегу относительно ингредиента
+)
+
+// GetRecommendations — персональные рекомендации по избранному и игнору.
+// Профиль пользователя — веса ингредиентов (IDF) и тегов: плюс за каждый
+// избранный коктейль, где они есть, минус за каждый игнорируемый. Коктейль
+// оценивается суммой весов своих ингредиентов и тегов, нормированной на размер
+// рецепта. Because — избранный коктейль, на который рекомендация похожа больше всего
+func GetRecommendations(ctx context.Context, db *sql.DB, userID int64, limit int) ([]Recommendation, error) {
+	rows, err := db.QueryContext(ctx, `
+		WITH fav AS (
+			SELECT cocktail_id FROM favorites WHERE user_id = $1
+		),
+		ign AS (
+			SELECT cocktail_id FROM ignored WHERE user_id = $1
+		),
+		signal AS (
+			SELECT cocktail_id, 1.0 AS sign FROM fav
+			UNION ALL
+			SELECT cocktail_id, -$2::float FROM ign
+		),
+		gw AS (
+			SELECT good_id, LN((SELECT COUNT(*) FROM cocktails)::float / COUNT(DISTINCT cocktail_id)) + 1 AS w
+			FROM cocktail_ingredients GROUP BY good_id
+		),
+		ing_profile AS (
+			SELECT ci.good_id, SUM(s.sign * gw.w) AS weight
+			FROM signal s
+			JOIN cocktail_ingredients ci ON ci.cocktail_id = s.cocktail_id
+			JOIN gw ON gw.good_id = ci.good_id
+			GROUP BY ci.good_id
+		),
+		tag_profile AS (
+			SELECT ct.tag_id, SUM(s.sign) * $3 AS weight
+			FROM signal s
+			JOIN cocktail_tags ct ON ct.cocktail_id = s.cocktail_id
+			GROUP BY ct.tag_id
+		),
+		candidates AS (
+			SELECT c.id FROM cocktails c
+			WHERE c.id NOT IN (SELECT cocktail_id FROM signal)
+		),
+		ing_score AS (
+			SELECT ci.cocktail_id, SUM(p.weight) AS score, COUNT(*) AS n
+			FROM cocktail_ingredients ci
+			JOIN candidates cd ON cd.id = ci.cocktail_id
+			LEFT JOIN ing_profile p ON p.good_id = ci.good_id
+			GROUP BY ci.cocktail_id
+		),
+		tag_score AS (
+			SELECT ct.cocktail_id, SUM(p.weight) AS score
+			FROM cocktail_tags ct
+			JOIN candidates cd ON cd.id = ct.cocktail_id
+			JOIN tag_profile p ON p.tag_id = ct.tag_id
+			GROUP BY ct.cocktail_id
+		),
+		scored AS (
+			SELECT i.cocktail_id,
+			       (COALESCE(i.score, 0) + COALESCE(t.score, 0)) / SQRT(i.n) AS score
+			FROM ing_score i
+			LEFT JOIN tag_score t ON t.cocktail_id = i.cocktail_id
+		)
+		SELECT c.id, c.name, c.url, c.image_url, c.instructions, s.score,
+		       COALESCE(because.name, ''), COALESCE(because.id, 0)
+		FROM scored s
+		JOIN cocktails c ON c.id = s.cocktail_id
+		LEFT JOIN LATERAL (
+			SELECT fc.id, fc.name
+			FROM cocktail_similarity cs
+			JOIN fav ON fav.cocktail_id = cs.cocktail_id
+			JOIN cocktails fc ON fc.id = cs.cocktail_id
+			WHERE cs.similar_id = c.id
+			ORDER BY cs.score DESC
+			LIMIT 1
+		) because ON TRUE
+		WHERE s.score > 0
+		ORDER BY s.score DESC, c.name
+		LIMIT $4;
+	`, userID, ignorePenalty, tagWeight, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Recommendation
+	for rows.Next() {
+		var r Recommendation
+		if err := rows.Scan(&r.ID, &r.Name, &r.URL, &r.ImageURL, &r.Instructions, &r.Score, &r.Because, &r.BecauseID); err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
@@ -0,0 +1,249 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// migrations — таблицы и индексы, которые бот создаёт сам при запуске.
+// Базовые таблицы (cocktails, goods, cocktail_ingredients, favorites, ignored)
+// создаются заранее; здесь только дополнения к ним. Все выражения идемпотентны.
+var migrations = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm;`,
+
+	// Домашний бар пользователя
+	`CREATE TABLE IF NOT EXISTS bar_items (
+		user_id    BIGINT NOT NULL,
+		good_id    INT NOT NULL REFERENCES goods(id) ON DELETE CASCADE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (user_id, good_id)
+	);`,
+
+	// Полнотекстовый поиск: названия ингредиентов денормализованы в
+	// cocktails.ingredients_text (generated-колонка не может ссылаться на
+	// другие таблицы), SaveRecipes обновляет его после каждого сохранения
+	`ALTER TABLE cocktails ADD COLUMN IF NOT EXISTS ingredients_text TEXT NOT NULL DEFAULT '';`,
+	`UPDATE cocktails c SET ingredients_text = sub.names
+	FROM (
+		SELECT ci.cocktail_id, string_agg(g.name, ' ' ORDER BY g.name) AS names
+		FROM cocktail_ingredients ci JOIN goods g ON g.id = ci.good_id
+		GROUP BY ci.cocktail_id
+	) sub
+	WHERE c.id = sub.cocktail_id AND c.ingredients_text = '';`,
+	`ALTER TABLE cocktails ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('russian', coalesce(ingredients_text, '')), 'B') ||
+			setweight(to_tsvector('russian', coalesce(instructions, '')), 'C')
+		) STORED;`,
+	`CREATE INDEX IF NOT EXISTS cocktails_search_vector_idx ON cocktails USING GIN (search_vector);`,
+
+	// Синонимы ингредиентов: "водочка", "vodka" → Водка. alias хранится в NormalizeAlias
+	`CREATE TABLE IF NOT EXISTS good_aliases (
+		alias      TEXT PRIMARY KEY,
+		good_id    INT NOT NULL REFERENCES goods(id) ON DELETE CASCADE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);`,
+	`CREATE INDEX IF NOT EXISTS good_aliases_trgm_idx ON good_aliases USING GIN (alias gin_trgm_ops);`,
+
+	// Иерархия ингредиентов: Белый ром → Ром → Крепкий алкоголь
+	`ALTER TABLE goods ADD COLUMN IF NOT EXISTS parent_id INT REFERENCES goods(id) ON DELETE SET NULL;`,
+	`CREATE INDEX IF NOT EXISTS goods_parent_id_idx ON goods (parent_id);`,
+
+	// Замены ингредиентов: source = 'curated' (вручную) или 'stats' (по рецептам)
+	`CREATE TABLE IF NOT EXISTS good_substitutions (
+		good_id       INT NOT NULL REFERENCES goods(id) ON DELETE CASCADE,
+		substitute_id INT NOT NULL REFERENCES goods(id) ON DELETE CASCADE,
+		source        TEXT NOT NULL DEFAULT 'curated',
+		score         DOUBLE PRECISION NOT NULL DEFAULT 1,
+		PRIMARY KEY (good_id, substitute_id)
+	);`,
+
+	// Теги коктейлей и крепость (выводится из тегов при сохранении)
+	`CREATE TABLE IF NOT EXISTS tags (
+		id   SERIAL PRIMARY KEY,
+		name TEXT NOT NULL UNIQUE
+	);`,
+	`CREATE TABLE IF NOT EXISTS cocktail_tags (
+		cocktail_id INT NOT NULL REFERENCES cocktails(id) ON DELETE CASCADE,
+		tag_id      INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+		PRIMARY KEY (cocktail_id, tag_id)
+	);`,
+	`ALTER TABLE cocktails ADD COLUMN IF NOT EXISTS strength TEXT NOT NULL DEFAULT '';`,
+
+	// Подписка "коктейль дня": время — минуты от полуночи в часовом поясе пользователя
+	`CREATE TABLE IF NOT EXISTS daily_subscriptions (
+		user_id      BIGINT PRIMARY KEY,
+		chat_id      BIGINT NOT NULL,
+		send_minute  INT NOT NULL CHECK (send_minute >= 0 AND send_minute < 1440),
+		timezone     TEXT NOT NULL DEFAULT 'Europe/Moscow',
+		last_sent_on DATE,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);`,
+	`CREATE TABLE IF NOT EXISTS daily_history (
+		user_id     BIGINT NOT NULL,
+		cocktail_id INT NOT NULL REFERENCES cocktails(id) ON DELETE CASCADE,
+		sent_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (user_id, cocktail_id)
+	);`,
+
+	// Похожие коктейли: предрасчёт RefreshSimilarity после каждого парсинга
+	`CREATE TABLE IF NOT EXISTS cocktail_similarity (
+		cocktail_id INT NOT NULL REFERENCES cocktails(id) ON DELETE CASCADE,
+		similar_id  INT NOT NULL REFERENCES cocktails(id) ON DELETE CASCADE,
+		score       DOUBLE PRECISION NOT NULL,
+		PRIMARY KEY (cocktail_id, similar_id)
+	);`,
+
+	// Личные оценки и заметки к коктейлям
+	`CREATE TABLE IF NOT EXISTS ratings (
+		user_id     BIGINT NOT NULL,
+		cocktail_id INT NOT NULL REFERENCES cocktails(id) ON DELETE CASCADE,
+		rating      SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+		updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (user_id, cocktail_id)
+	);`,
+	`CREATE TABLE IF NOT EXISTS notes (
+		user_id     BIGINT NOT NULL,
+		cocktail_id INT NOT NULL REFERENCES cocktails(id) ON DELETE CASCADE,
+		note        TEXT NOT NULL,
+		updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (user_id, cocktail_id)
+	);`,
+
+	// Пользователи: обновляются на каждом апдейте (bot.TrackUser)
+	`CREATE TABLE IF NOT EXISTS users (
+		id            BIGINT PRIMARY KEY,
+		username      TEXT NOT NULL DEFAULT '',
+		first_name    TEXT NOT NULL DEFAULT '',
+		language_code TEXT NOT NULL DEFAULT '',
+		first_seen    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		last_active   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		blocked       BOOLEAN NOT NULL DEFAULT FALSE
+	);`,
+	// пользователи, которые уже есть в пользовательских таблицах
+	`INSERT INTO users (id)
+	SELECT user_id FROM favorites
+	UNION SELECT user_id FROM ignored
+	UNION SELECT user_id FROM bar_items
+	UNION SELECT user_id FROM daily_subscriptions
+	UNION SELECT user_id FROM ratings
+	UNION SELECT user_id FROM notes
+	ON CONFLICT (id) DO NOTHING;`,
+	addUserForeignKey("bar_items"),
+	addUserForeignKey("daily_subscriptions"),
+	addUserForeignKey("ratings"),
+	addUserForeignKey("notes"),
+	// Язык интерфейса, выбранный через /lang (пусто — по language_code из Telegram)
+	`ALTER TABLE users ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT '';`,
+
+	// Переводы с других языковых версий Inshaker (SaveTranslations).
+	// Исходные названия в cocktails/goods — русские
+	`CREATE TABLE IF NOT EXISTS cocktail_translations (
+		cocktail_id  INT NOT NULL REFERENCES cocktails(id) ON DELETE CASCADE,
+		locale       TEXT NOT NULL,
+		name         TEXT NOT NULL,
+		url          TEXT NOT NULL DEFAULT '',
+		instructions TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (cocktail_id, locale)
+	);`,
+	`ALTER TABLE cocktail_translations ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(instructions, '')), 'C')
+		) STORED;`,
+	`CREATE INDEX IF NOT EXISTS cocktail_translations_search_vector_idx ON cocktail_translations USING GIN (search_vector);`,
+	`CREATE INDEX IF NOT EXISTS cocktail_translations_name_trgm_idx ON cocktail_translations USING GIN (LOWER(name) gin_trgm_ops);`,
+	`CREATE TABLE IF NOT EXISTS good_translations (
+		good_id INT NOT NULL REFERENCES goods(id) ON DELETE CASCADE,
+		locale  TEXT NOT NULL,
+		name    TEXT NOT NULL,
+		PRIMARY KEY (good_id, locale)
+	);`,
+	`CREATE INDEX IF NOT EXISTS good_translations_name_trgm_idx ON good_translations USING GIN (LOWER(name) gin_trgm_ops);`,
+	// единицы измерения: "мл" → "ml", собираются по парам ингредиентов
+	`CREATE TABLE IF NOT EXISTS unit_translations (
+		unit   TEXT NOT NULL,
+		locale TEXT NOT NULL,
+		name   TEXT NOT NULL,
+		PRIMARY KEY (unit, locale)
+	);`,
+
+	// Журнал действий администраторов (/admin, /alias)
+	`CREATE TABLE IF NOT EXISTS admin_audit (
+		id         SERIAL PRIMARY KEY,
+		admin_id   BIGINT NOT NULL,
+		action     TEXT NOT NULL,
+		args       TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);`,
+
+	// Рассылки: draft → sending → done (или cancelled). Получатели фиксируются
+	// при подтверждении, их статус позволяет продолжить рассылку после перезапуска
+	`CREATE TABLE IF NOT EXISTS broadcasts (
+		id          SERIAL PRIMARY KEY,
+		admin_id    BIGINT NOT NULL,
+		chat_id     BIGINT NOT NULL,
+		text        TEXT NOT NULL,
+		status      TEXT NOT NULL DEFAULT 'draft',
+		delivered   INT NOT NULL DEFAULT 0,
+		failed      INT NOT NULL DEFAULT 0,
+		created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		started_at  TIMESTAMPTZ,
+		finished_at TIMESTAMPTZ
+	);`,
+	`CREATE TABLE IF NOT EXISTS broadcast_recipients (
+		broadcast_id INT NOT NULL REFERENCES broadcasts(id) ON DELETE CASCADE,
+		user_id      BIGINT NOT NULL,
+		status       TEXT NOT NULL DEFAULT 'pending',
+		PRIMARY KEY (broadcast_id, user_id)
+	);`,
+	`CREATE INDEX IF NOT EXISTS broadcast_recipients_pending_idx
+		ON broadcast_recipients (broadcast_id) WHERE status = 'pending';`,
+
+	// Общий бар вечеринки в групповом чате: кто что принесёт
+	`CREATE TABLE IF NOT EXISTS party_items (
+		chat_id    BIGINT NOT NULL,
+		good_id    INT NOT NULL REFERENCES goods(id) ON DELETE CASCADE,
+		user_id    BIGINT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (chat_id, good_id)
+	);`,
+
+	// Служебные узлы иерархии ("Ликеры", "Цитрусовые") — не ингредиенты:
+	// по ним ищут, но в бар, статистику замен и счётчики они не попадают
+	`ALTER TABLE goods ADD COLUMN IF NOT EXISTS is_category BOOLEAN NOT NULL DEFAULT FALSE;`,
+
+	// started — пользователь писал боту в личку, и бот может ему написать первым.
+	// До групповых чатов все пользователи приходили из лички, поэтому уже
+	// существующие строки получают TRUE, а новые — FALSE
+	`ALTER TABLE users ADD COLUMN IF NOT EXISTS started BOOLEAN NOT NULL DEFAULT TRUE;`,
+	`ALTER TABLE users ALTER COLUMN started SET DEFAULT FALSE;`,
+
+	// Нечёткий поиск по названиям (pg_trgm): выражения те же, что в
+	// SearchCocktailsByName и SuggestGoods, иначе индекс не используется
+	`CREATE INDEX IF NOT EXISTS cocktails_name_trgm_idx ON cocktails USING GIN (LOWER(name) gin_trgm_ops);`,
+	`CREATE INDEX IF NOT EXISTS goods_name_trgm_idx ON goods USING GIN (replace(LOWER(name), 'ё', 'е') gin_trgm_ops);`,
+}
+
+// addUserForeignKey — внешний ключ table.user_id → users(id), если его ещё нет
+func addUserForeignKey(table string) string {
+	return fmt.Sprintf(`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = '%[1]s_user_id_fkey') THEN
+			ALTER TABLE %[1]s ADD CONSTRAINT %[1]s_user_id_fkey
+				FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+		END IF;
+	END $$;`, table)
+}
+
+// Migrate — применяет migrations по порядку
+func Migrate(ctx context.Context, db *sql.DB) error {
+	for i, stmt := range migrations {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("миграция #%d: %w", i, err)
+		}
+	}
+	return nil
+}
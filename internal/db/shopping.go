@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// GetCocktailsByIDs — получить коктейли по списку ID
+func GetCocktailsByIDs(ctx context.Context, db *sql.DB, ids []int) ([]Cocktail, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT c.id, c.name, c.url, c.image_url, c.instructions
+		FROM cocktails c
+		WHERE c.id = ANY($1)
+		ORDER BY c.name;
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Cocktail
+	for rows.Next() {
+		var c Cocktail
+		if err := rows.Scan(&c.ID, &c.Name, &c.URL, &c.ImageURL, &c.Instructions); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+// GetCocktailIngredients — ингредиенты выбранных коктейлей (по одной порции)
+func GetCocktailIngredients(ctx context.Context, db *sql.DB, cocktailIDs []int) ([]CocktailIngredient, error) {
+	if len(cocktailIDs) == 0 {
+		return nil, fmt.Errorf("список коктейлей пуст")
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT ci.id, ci.cocktail_id, ci.good_id, g.name, ci.amount, ci.unit
+		FROM cocktail_ingredients ci
+		JOIN goods g ON ci.good_id = g.id
+		WHERE ci.cocktail_id = ANY($1)
+		ORDER BY g.name;
+	`, pq.Array(cocktailIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []CocktailIngredient
+	for rows.Next() {
+		var ci CocktailIngredient
+		if err := rows.Scan(&ci.ID, &ci.CocktailID, &ci.GoodID, &ci.Good.Name, &ci.Amount, &ci.Unit); err != nil {
+			return nil, err
+		}
+		ci.Good.ID = ci.GoodID
+		result = append(result, ci)
+	}
+	return result, nil
+}
+
+// BuildShoppingList — суммирует ингредиенты по good_id и единице измерения
+// на заданное число порций, пропуская то, что у пользователя уже есть
+func BuildShoppingList(items []CocktailIngredient, servings int, have map[int]bool) []ShoppingItem {
+	if servings < 1 {
+		servings = 1
+	}
+
+	type key struct {
+		goodID int
+		unit   string
+	}
+	index := make(map[key]int)
+	var list []ShoppingItem
+
+	for _, ing := range items {
+		if have[ing.GoodID] {
+			continue
+		}
+
+		k := key{ing.GoodID, ing.Unit}
+		i, ok := index[k]
+		if !ok {
+			list = append(list, ShoppingItem{GoodID: ing.GoodID, Name: ing.Good.Name, Unit: ing.Unit})
+			i = len(list) - 1
+			index[k] = i
+		}
+
+		if v, ok := parseAmount(ing.Amount); ok {
+			list[i].Amount += v * float64(servings)
+		} else {
+			// "по вкусу", "щепотка" и т.п. — количество не суммируется
+			list[i].Unknown = true
+		}
+	}
+
+	sort.SliceStable(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+	return list
+}
+
+// parseAmount — разбирает количество вида "50", "0,5", "1/2"
+func parseAmount(s string) (float64, bool) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", ".")
+	if s == "" {
+		return 0, false
+	}
+
+	if num, den, ok := strings.Cut(s, "/"); ok {
+		n, err1 := strconv.ParseFloat(strings.TrimSpace(num), 64)
+		d, err2 := strconv.ParseFloat(strings.TrimSpace(den), 64)
+		if err1 != nil || err2 != nil || d == 0 {
+			return 0, false
+		}
+		return n / d, true
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// FormatAmount — печатает количество без лишних нулей: 1.5, 60, 0.33
+func FormatAmount(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
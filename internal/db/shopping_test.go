@@ -0,0 +1,106 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"50", 50, true},
+		{" 20 ", 20, true},
+		{"0,5", 0.5, true},
+		{"1.5", 1.5, true},
+		{"1/2", 0.5, true},
+		{"1 / 4", 0.25, true},
+		{"1/0", 0, false},
+		{"", 0, false},
+		{"по вкусу", 0, false},
+		{"a/2", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseAmount(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseAmount(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{60, "60"},
+		{1.5, "1.5"},
+		{1.0 / 3, "0.33"},
+		{0.005, "0.01"},
+	}
+	for _, tt := range tests {
+		if got := FormatAmount(tt.in); got != tt.want {
+			t.Errorf("FormatAmount(%v) = %q; want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBuildShoppingList(t *testing.T) {
+	ing := func(goodID int, name, amount, unit string) CocktailIngredient {
+		return CocktailIngredient{GoodID: goodID, Good: Good{ID: goodID, Name: name}, Amount: amount, Unit: unit}
+	}
+	items := []CocktailIngredient{
+		ing(1, "Ром", "50", "мл"),
+		ing(2, "Лайм", "1/2", "шт"),
+		ing(1, "Ром", "40", "мл"),
+		ing(3, "Мята", "по вкусу", ""),
+		ing(2, "Лайм", "20", "мл"),
+		ing(4, "Сахар", "2", "ч.л."),
+	}
+
+	tests := []struct {
+		name     string
+		servings int
+		have     map[int]bool
+		want     []ShoppingItem
+	}{
+		{
+			name:     "одна порция",
+			servings: 1,
+			want: []ShoppingItem{
+				{GoodID: 2, Name: "Лайм", Amount: 0.5, Unit: "шт"},
+				{GoodID: 2, Name: "Лайм", Amount: 20, Unit: "мл"},
+				{GoodID: 3, Name: "Мята", Unknown: true},
+				{GoodID: 1, Name: "Ром", Amount: 90, Unit: "мл"},
+				{GoodID: 4, Name: "Сахар", Amount: 2, Unit: "ч.л."},
+			},
+		},
+		{
+			name:     "порции умножаются, имеющееся пропускается",
+			servings: 3,
+			have:     map[int]bool{2: true, 4: true},
+			want: []ShoppingItem{
+				{GoodID: 3, Name: "Мята", Unknown: true},
+				{GoodID: 1, Name: "Ром", Amount: 270, Unit: "мл"},
+			},
+		},
+		{
+			name:     "неположительное число порций считается одной",
+			servings: 0,
+			have:     map[int]bool{2: true, 3: true, 4: true},
+			want: []ShoppingItem{
+				{GoodID: 1, Name: "Ром", Amount: 90, Unit: "мл"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildShoppingList(items, tt.servings, tt.have)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildShoppingList() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
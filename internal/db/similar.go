@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log"
+)
+
+const similarPerCocktail = 20 // сколько похожих хранить на каждый коктейль
+
+// RefreshSimilarity — пересчитывает таблицу похожих коктейлей. Сходство —
+// взвешенный коэффициент Жаккара по ингредиентам: вес ингредиента как в IDF,
+// так что общий редкий ингредиент сближает сильнее, чем лёд или сахарный сироп
+func RefreshSimilarity(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM cocktail_similarity;`); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		WITH ci AS (
+			SELECT DISTINCT cocktail_id, good_id FROM cocktail_ingredients
+		),
+		w AS (
+			SELECT good_id, LN((SELECT COUNT(*) FROM cocktails)::float / COUNT(*)) + 1 AS weight
+			FROM ci GROUP BY good_id
+		),
+		totals AS (
+			SELECT ci.cocktail_id, SUM(w.weight) AS total
+			FROM ci JOIN w USING (good_id)
+			GROUP BY ci.cocktail_id
+		),
+		shared AS (
+			SELECT a.cocktail_id AS a, b.cocktail_id AS b, SUM(w.weight) AS common
+			FROM ci a
+			JOIN ci b ON a.good_id = b.good_id AND a.cocktail_id <> b.cocktail_id
+			JOIN w ON w.good_id = a.good_id
+			GROUP BY a.cocktail_id, b.cocktail_id
+		),
+		scored AS (
+			SELECT s.a, s.b, s.common / (ta.total + tb.total - s.common) AS score
+			FROM shared s
+			JOIN totals ta ON ta.cocktail_id = s.a
+			JOIN totals tb ON tb.cocktail_id = s.b
+		),
+		ranked AS (
+			SELECT a, b, score, ROW_NUMBER() OVER (PARTITION BY a ORDER BY score DESC, b) AS rn
+			FROM scored
+		)
+		INSERT INTO cocktail_similarity (cocktail_id, similar_id, score)
+		SELECT a, b, score FROM ranked WHERE rn <= $1;
+	`, similarPerCocktail)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// EnsureSimilarity — считает похожие коктейли, если таблица ещё пуста
+func EnsureSimilarity(ctx context.Context, db *sql.DB) error {
+	var exists bool
+	if err := db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM cocktail_similarity);`).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	log.Println("🔗 Считаем похожие коктейли...")
+	return RefreshSimilarity(ctx, db)
+}
+
+// GetSimilarCocktails — самые похожие на коктейль, без игнорируемых пользователем
+func GetSimilarCocktails(ctx context.Context, db *sql.DB, userID int64, cocktailID, limit int) ([]CocktailMatch, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT c.id, c.name, c.url, c.image_url, c.instructions, s.score
+		FROM cocktail_similarity s
+		JOIN cocktails c ON c.id = s.similar_id
+		WHERE s.cocktail_id = $2
+		  AND NOT EXISTS (
+			SELECT 1 FROM ignored i WHERE i.user_id = $1 AND i.cocktail_id = c.id
+		  )
+		ORDER BY s.score DESC, c.name
+		LIMIT $3;
+	`, userID, cocktailID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []CocktailMatch
+	for rows.Next() {
+		var m CocktailMatch
+		if err := rows.Scan(&m.ID, &m.Name, &m.URL, &m.ImageURL, &m.Instructions, &m.Score); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
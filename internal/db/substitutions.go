@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// Параметры статистических замен
+const (
+	substituteMinScore = 0.2 // минимальное сходство контекстов (Жаккар)
+	substitutePerGood  = 3   // сколько статистических замен хранить на ингредиент
+)
+
+// defaultSubstitutions — курируемые взаимозаменяемые пары (в обе стороны)
+var defaultSubstitutions = [][2]string{
+	{"Трипл сек", "Апельсиновый ликер"},
+	{"Сок лайма", "Сок лимона"},
+	{"Лайм", "Лимон"},
+	{"Сахарный сироп", "Сироп агавы"},
+	{"Сахарный сироп", "Тростниковый сироп"},
+	{"Белый ром", "Золотой ром"},
+	{"Бурбон", "Ржаной виски"},
+	{"Просекко", "Шампанское"},
+	{"Содовая", "Минеральная вода с газом"},
+	{"Ангостура биттер", "Апельсиновый биттер"},
+	{"Сухой вермут", "Белый вермут"},
+}
+
+// SeedSubstitutions — курируемые замены для ингредиентов, которые есть в goods,
+// и пересчёт статистических замен
+func SeedSubstitutions(ctx context.Context, db *sql.DB) error {
+	for _, pair := range defaultSubstitutions {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO good_substitutions (good_id, substitute_id, source, score)
+			SELECT a.id, b.id, 'curated', 1
+			FROM goods a, goods b
+			WHERE (LOWER(a.name), LOWER(b.name)) IN ((LOWER($1), LOWER($2)), (LOWER($2), LOWER($1)))
+			ON CONFLICT (good_id, substitute_id) DO UPDATE
+			    SET source = 'curated', score = 1;
+		`, pair[0], pair[1])
+		if err != nil {
+			return fmt.Errorf("замена %s ↔ %s: %w", pair[0], pair[1], err)
+		}
+	}
+	return RefreshSubstitutionStats(ctx, db)
+}
+
+// RefreshSubstitutionStats — пересчитывает статистические замены по cocktail_ingredients.
+// Кандидаты — соседи по иерархии goods (общий родитель), которые почти не встречаются
+// в одном рецепте, но окружены похожими ингредиентами: оценка — коэффициент Жаккара
+// множеств "с чем сочетается"
+func RefreshSubstitutionStats(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM good_substitutions WHERE source = 'stats';`); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		WITH ctx AS (
+			SELECT DISTINCT a.good_id AS good, b.good_id AS other
+			FROM cocktail_ingredients a
+			JOIN cocktail_ingredients b ON a.cocktail_id = b.cocktail_id AND a.good_id <> b.good_id
+		),
+		sizes AS (
+			SELECT good, COUNT(*) AS n FROM ctx GROUP BY good
+		),
+		siblings AS (
+			SELECT a.id AS a, b.id AS b
+			FROM goods a JOIN goods b ON a.parent_id = b.parent_id AND a.id <> b.id
+			WHERE NOT a.is_category AND NOT b.is_category
+		),
+		scored AS (
+			SELECT s.a, s.b,
+			       COUNT(*)::float / (sa.n + sb.n - COUNT(*)) AS score
+			FROM siblings s
+			JOIN ctx ca ON ca.good = s.a
+			JOIN ctx cb ON cb.good = s.b AND cb.other = ca.other
+			JOIN sizes sa ON sa.good = s.a
+			JOIN sizes sb ON sb.good = s.b
+			WHERE NOT EXISTS (SELECT 1 FROM ctx WHERE ctx.good = s.a AND ctx.other = s.b)
+			GROUP BY s.a, s.b, sa.n, sb.n
+		),
+		ranked AS (
+			SELECT a, b, score, ROW_NUMBER() OVER (PARTITION BY a ORDER BY score DESC) AS rn
+			FROM scored
+			WHERE score >= $1
+		)
+		INSERT INTO good_substitutions (good_id, substitute_id, source, score)
+		SELECT a, b, 'stats', score FROM ranked WHERE rn <= $2
+		ON CONFLICT (good_id, substitute_id) DO NOTHING;
+	`, substituteMinScore, substitutePerGood)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetSubstitutes — чем можно заменить ингредиенты: для каждого goodID до limit
+// замен, сначала курируемые, затем статистические
+func GetSubstitutes(ctx context.Context, db *sql.DB, goodIDs []int, limit int) (map[int][]Substitute, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT good_id, id, name, source, score FROM (
+			SELECT s.good_id, g.id, g.name, s.source, s.score,
+			       ROW_NUMBER() OVER (
+			           PARTITION BY s.good_id
+			           ORDER BY s.source = 'curated' DESC, s.score DESC, g.name
+			       ) AS rn
+			FROM good_substitutions s
+			JOIN goods g ON g.id = s.substitute_id
+			WHERE s.good_id = ANY($1)
+		) t
+		WHERE rn <= $2
+		ORDER BY good_id, rn;
+	`, pq.Array(goodIDs), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[int][]Substitute)
+	for rows.Next() {
+		var goodID int
+		var s Substitute
+		var source string
+		if err := rows.Scan(&goodID, &s.ID, &s.Name, &source, &s.Score); err != nil {
+			return nil, err
+		}
+		s.Curated = source == "curated"
+		result[goodID] = append(result[goodID], s)
+	}
+	return result, nil
+}
+
+// GetOwnedGoodIDs — что есть у пользователя: бар и потомки общих ингредиентов из него
+func GetOwnedGoodIDs(ctx context.Context, db *sql.DB, userID int64) (map[int]bool, error) {
+	rows, err := db.QueryContext(ctx, `
+		WITH RECURSIVE owned AS (
+			SELECT good_id AS id FROM bar_items WHERE user_id = $1
+			UNION
+			SELECT g.id FROM owned JOIN goods g ON g.parent_id = owned.id
+		)
+		SELECT id FROM owned;
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	owned := make(map[int]bool)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		owned[id] = true
+	}
+	return owned, nil
+}
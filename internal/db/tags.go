@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Крепость коктейля
+const (
+	StrengthNone   = "none"   // безалкогольный
+	StrengthLow    = "low"    // слабоалкогольный
+	StrengthMedium = "medium" // среднеалкогольный
+	StrengthStrong = "strong" // крепкий
+)
+
+// strengthTags — теги Inshaker, по которым определяется крепость
+var strengthTags = map[string]string{
+	"безалкогольные":    StrengthNone,
+	"слабоалкогольные":  StrengthLow,
+	"среднеалкогольные": StrengthMedium,
+	"крепкие":           StrengthStrong,
+}
+
+// StrengthFromTags — крепость по тегам коктейля, "" если тега крепости нет
+func StrengthFromTags(tags []string) string {
+	for _, t := range tags {
+		if s, ok := strengthTags[strings.ToLower(strings.TrimSpace(t))]; ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// strengthWords — английские слова крепости. Сравниваются целиком, чтобы
+// "light rum" остался ингредиентом
+var strengthWords = map[string]string{
+	"non-alcoholic": StrengthNone,
+	"nonalcoholic":  StrengthNone,
+	"alcohol-free":  StrengthNone,
+	"virgin":        StrengthNone,
+	"light":         StrengthLow,
+	"low":           StrengthLow,
+	"weak":          StrengthLow,
+	"medium":        StrengthMedium,
+	"strong":        StrengthStrong,
+}
+
+// ParseStrength — крепость из пользовательского ввода: "крепкий", "слабый",
+// "безалкогольный" или "strong", "light", "non-alcoholic"
+func ParseStrength(s string) (string, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if strength, ok := strengthWords[s]; ok {
+		return strength, true
+	}
+	switch {
+	case s == "":
+		return "", false
+	case strings.HasPrefix(s, "безалк"):
+		return StrengthNone, true
+	case strings.HasPrefix(s, "слаб"):
+		return StrengthLow, true
+	case strings.HasPrefix(s, "сред"):
+		return StrengthMedium, true
+	case strings.HasPrefix(s, "креп"):
+		return StrengthStrong, true
+	}
+	return "", false
+}
+
+// saveCocktailTags — создаёт теги и связи коктейль ↔ тег
+func saveCocktailTags(ctx context.Context, db *sql.DB, cocktailID int, tags []string) error {
+	for _, name := range tags {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		_, err := db.ExecContext(ctx, `
+			WITH t AS (
+				INSERT INTO tags (name) VALUES ($2)
+				ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+				RETURNING id
+			)
+			INSERT INTO cocktail_tags (cocktail_id, tag_id)
+			SELECT $1, id FROM t
+			ON CONFLICT DO NOTHING;
+		`, cocktailID, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRandomCocktail — случайный коктейль с учётом фильтра, без игнорируемых
+// и без уже показанных (exclude). sql.ErrNoRows — подходящих не осталось
+func GetRandomCocktail(ctx context.Context, db *sql.DB, userID int64, f RandomFilter, exclude []int) (Cocktail, error) {
+	if exclude == nil {
+		exclude = []int{}
+	}
+
+	var c Cocktail
+	err := db.QueryRowContext(ctx, `
+		WITH RECURSIVE tree AS (
+			SELECT id FROM goods WHERE id = $2
+			UNION
+			SELECT g.id FROM tree JOIN goods g ON g.parent_id = tree.id
+		)
+		SELECT c.id, c.name, c.url, c.image_url, c.instructions, c.strength
+		FROM cocktails c
+		WHERE NOT EXISTS (
+			SELECT 1 FROM ignored i WHERE i.user_id = $1 AND i.cocktail_id = c.id
+		)
+		  AND c.id <> ALL($5)
+		  AND ($2 = 0 OR EXISTS (
+			SELECT 1 FROM cocktail_ingredients ci
+			WHERE ci.cocktail_id = c.id AND ci.good_id IN (SELECT id FROM tree)
+		  ))
+		  AND ($3 = '' OR EXISTS (
+			SELECT 1 FROM cocktail_tags ct JOIN tags t ON t.id = ct.tag_id
+			WHERE ct.cocktail_id = c.id AND LOWER(t.name) = LOWER($3)
+		  ))
+		  AND ($4 = '' OR c.strength = $4)
+		ORDER BY random()
+		LIMIT 1;
+	`, userID, f.GoodID, f.Tag, f.Strength, pq.Array(exclude)).Scan(
+		&c.ID, &c.Name, &c.URL, &c.ImageURL, &c.Instructions, &c.Strength)
+	return c, err
+}
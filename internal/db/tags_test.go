@@ -0,0 +1,50 @@
+package db
+
+import "testing"
+
+func TestParseStrength(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"безалкогольный", StrengthNone, true},
+		{"Безалкогольные", StrengthNone, true},
+		{"слабый", StrengthLow, true},
+		{"слабоалкогольные", StrengthLow, true},
+		{"средний", StrengthMedium, true},
+		{" крепкий ", StrengthStrong, true},
+		{"non-alcoholic", StrengthNone, true},
+		{"virgin", StrengthNone, true},
+		{"light", StrengthLow, true},
+		{"Weak", StrengthLow, true},
+		{"medium", StrengthMedium, true},
+		{"STRONG", StrengthStrong, true},
+		{"light rum", "", false},
+		{"ром", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseStrength(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseStrength(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestStrengthFromTags(t *testing.T) {
+	tests := []struct {
+		tags []string
+		want string
+	}{
+		{[]string{"Шот", "Крепкие"}, StrengthStrong},
+		{[]string{" безалкогольные "}, StrengthNone},
+		{[]string{"Сладкие", "Цитрусовые"}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := StrengthFromTags(tt.tags); got != tt.want {
+			t.Errorf("StrengthFromTags(%q) = %q; want %q", tt.tags, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// taxonomyNode — узел встроенной иерархии ингредиентов
+type taxonomyNode struct {
+	Name     string   // узел; если такого ингредиента нет в goods, он создаётся
+	Category bool     // служебный узел, а не ингредиент: ищется, но не кладётся в бар
+	Parent   string   // родительский узел, "" — корень
+	Word     string   // потомки — все goods, где это слово встречается целиком
+	Children []string // потомки по точному названию
+}
+
+// defaultTaxonomy — семейства и типы, под которые раскладываются товары Inshaker.
+// Порядок важен: родитель должен идти раньше потомков
+var defaultTaxonomy = []taxonomyNode{
+	{Name: "Крепкий алкоголь", Category: true},
+	{Name: "Ром", Parent: "Крепкий алкоголь", Word: "ром"},
+	{Name: "Джин", Parent: "Крепкий алкоголь", Word: "джин"},
+	{Name: "Водка", Parent: "Крепкий алкоголь", Word: "водка"},
+	{Name: "Виски", Parent: "Крепкий алкоголь", Word: "виски"},
+	{Name: "Текила", Parent: "Крепкий алкоголь", Word: "текила"},
+	{Name: "Коньяк", Parent: "Крепкий алкоголь", Word: "коньяк"},
+	{Name: "Ликеры", Category: true, Word: "ликер"},
+	{Name: "Вермуты", Category: true, Word: "вермут"},
+	{Name: "Биттеры", Category: true, Word: "биттер"},
+	{Name: "Сиропы", Category: true, Word: "сироп"},
+	{Name: "Цитрусовые", Category: true, Children: []string{
+		"Лайм", "Лимон", "Апельсин", "Грейпфрут",
+		"Сок лайма", "Сок лимона", "Апельсиновый сок", "Грейпфрутовый сок",
+	}},
+}
+
+// SeedTaxonomy — раскладывает goods по встроенной иерархии. Уже заданных
+// родителей не трогает, так что ручные правки в базе сохраняются.
+// Слова сравниваются без различия ё/е: "ликер" находит "Ликёр Куантро"
+func SeedTaxonomy(ctx context.Context, db *sql.DB) error {
+	for _, node := range defaultTaxonomy {
+		var nodeID int
+		err := db.QueryRowContext(ctx, `
+			INSERT INTO goods (name, is_category) VALUES ($1, $2)
+			ON CONFLICT (name) DO UPDATE SET is_category = EXCLUDED.is_category
+			RETURNING id;
+		`, node.Name, node.Category).Scan(&nodeID)
+		if err != nil {
+			return fmt.Errorf("узел %s: %w", node.Name, err)
+		}
+
+		if node.Parent != "" {
+			_, err = db.ExecContext(ctx, `
+				UPDATE goods SET parent_id = (SELECT id FROM goods WHERE name = $2)
+				WHERE id = $1 AND parent_id IS NULL;
+			`, nodeID, node.Parent)
+			if err != nil {
+				return fmt.Errorf("родитель %s: %w", node.Name, err)
+			}
+		}
+
+		if node.Word != "" {
+			_, err = db.ExecContext(ctx, `
+				UPDATE goods SET parent_id = $1
+				WHERE parent_id IS NULL AND id <> $1
+				  AND replace(LOWER(name), 'ё', 'е') ~ ('\m' || $2 || '\M');
+			`, nodeID, NormalizeAlias(node.Word))
+			if err != nil {
+				return fmt.Errorf("потомки %s: %w", node.Name, err)
+			}
+		}
+
+		for _, child := range node.Children {
+			_, err = db.ExecContext(ctx, `
+				UPDATE goods SET parent_id = $1
+				WHERE parent_id IS NULL AND id <> $1 AND name = $2;
+			`, nodeID, child)
+			if err != nil {
+				return fmt.Errorf("потомок %s: %w", child, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"regexp"
+
+	"github.com/lib/pq"
+)
+
+// LocaleRU — язык исходных данных в cocktails и goods
+const LocaleRU = "ru"
+
+// recipeKeyRe — номер рецепта в URL Inshaker, общий для всех языковых версий:
+// ru.inshaker.com/cocktails/35-mohito ↔ en.inshaker.com/cocktails/35-mojito
+var recipeKeyRe = regexp.MustCompile(`/cocktails/(\d+)`)
+
+// RecipeKey — номер рецепта из URL, "" если его нет
+func RecipeKey(url string) string {
+	if m := recipeKeyRe.FindStringSubmatch(url); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// SaveTranslations — сохраняет рецепты с другой языковой версии сайта как переводы.
+// Рецепт сопоставляется с русским по номеру в URL, ингредиенты — по порядку в рецепте
+// (если их число совпадает). Возвращает число сопоставленных рецептов
+func SaveTranslations(ctx context.Context, db *sql.DB, locale string, cocktails []Cocktail) (int, error) {
+	matched := 0
+	for _, c := range cocktails {
+		key := RecipeKey(c.URL)
+		if key == "" {
+			continue
+		}
+
+		var cocktailID int
+		err := db.QueryRowContext(ctx, `
+			SELECT id FROM cocktails
+			WHERE substring(url FROM '/cocktails/([0-9]+)') = $1
+			LIMIT 1;
+		`, key).Scan(&cocktailID)
+		if err == sql.ErrNoRows {
+			log.Printf("ℹ️ Нет русского рецепта для %s (%s)", c.Name, c.URL)
+			continue
+		}
+		if err != nil {
+			return matched, err
+		}
+
+		_, err = db.ExecContext(ctx, `
+			INSERT INTO cocktail_translations (cocktail_id, locale, name, url, instructions)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (cocktail_id, locale) DO UPDATE
+			    SET name = EXCLUDED.name,
+			        url = EXCLUDED.url,
+			        instructions = EXCLUDED.instructions;
+		`, cocktailID, locale, c.Name, c.URL, c.Instructions)
+		if err != nil {
+			return matched, err
+		}
+		matched++
+
+		if err := saveIngredientTranslations(ctx, db, locale, cocktailID, c); err != nil {
+			log.Printf("⚠️ Ошибка сохранения переводов ингредиентов %s: %v", c.Name, err)
+		}
+	}
+
+	log.Printf("✅ Сопоставлено %d из %d рецептов (%s)", matched, len(cocktails), locale)
+	return matched, nil
+}
+
+// saveIngredientTranslations — переводит ингредиенты и единицы рецепта по порядку.
+// cocktail_ingredients.id растёт в порядке парсинга, поэтому порядок совпадает с сайтом
+func saveIngredientTranslations(ctx context.Context, db *sql.DB, locale string, cocktailID int, c Cocktail) error {
+	rows, err := db.QueryContext(ctx, `
+		SELECT good_id, unit FROM cocktail_ingredients
+		WHERE cocktail_id = $1
+		ORDER BY id;
+	`, cocktailID)
+	if err != nil {
+		return err
+	}
+	type source struct {
+		goodID int
+		unit   string
+	}
+	var sources []source
+	for rows.Next() {
+		var s source
+		if err := rows.Scan(&s.goodID, &s.unit); err != nil {
+			rows.Close()
+			return err
+		}
+		sources = append(sources, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(sources) != len(c.Ingredients) {
+		log.Printf("ℹ️ %s: %d ингредиентов против %d в русском рецепте — пропускаем",
+			c.Name, len(c.Ingredients), len(sources))
+		return nil
+	}
+
+	for i, ing := range c.Ingredients {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO good_translations (good_id, locale, name)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (good_id, locale) DO UPDATE SET name = EXCLUDED.name;
+		`, sources[i].goodID, locale, ing.Good.Name)
+		if err != nil {
+			return err
+		}
+
+		if sources[i].unit == "" || ing.Unit == "" {
+			continue
+		}
+		_, err = db.ExecContext(ctx, `
+			INSERT INTO unit_translations (unit, locale, name)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (unit, locale) DO NOTHING;
+		`, sources[i].unit, locale, ing.Unit)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LocalizeCocktails — подставляет переводы названий, способа приготовления,
+// ингредиентов и единиц. Чего нет в переводе, остаётся по-русски
+func LocalizeCocktails(ctx context.Context, db *sql.DB, locale string, cocktails []Cocktail) error {
+	if locale == "" || locale == LocaleRU || len(cocktails) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(cocktails))
+	var goodIDs []int
+	for _, c := range cocktails {
+		ids = append(ids, c.ID)
+		for _, ing := range c.Ingredients {
+			goodIDs = append(goodIDs, ing.GoodID)
+		}
+	}
+
+	type translation struct{ name, url, instructions string }
+	names := make(map[int]translation)
+	rows, err := db.QueryContext(ctx, `
+		SELECT cocktail_id, name, url, instructions
+		FROM cocktail_translations
+		WHERE locale = $1 AND cocktail_id = ANY($2);
+	`, locale, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var id int
+		var t translation
+		if err := rows.Scan(&id, &t.name, &t.url, &t.instructions); err != nil {
+			rows.Close()
+			return err
+		}
+		names[id] = t
+	}
+	rows.Close()
+
+	goods, err := getGoodTranslations(ctx, db, locale, goodIDs)
+	if err != nil {
+		return err
+	}
+	units, err := getUnitTranslations(ctx, db, locale)
+	if err != nil {
+		return err
+	}
+
+	for i := range cocktails {
+		c := &cocktails[i]
+		if t, ok := names[c.ID]; ok {
+			c.Name = t.name
+			if t.url != "" {
+				c.URL = t.url
+			}
+			if t.instructions != "" {
+				c.Instructions = t.instructions
+			}
+		}
+		translateIngredients(c.Ingredients, goods, units)
+	}
+	return nil
+}
+
+// LocalizeIngredients — переводы названий ингредиентов и единиц без коктейлей
+// (список покупок). Чего нет в переводе, остаётся по-русски
+func LocalizeIngredients(ctx context.Context, db *sql.DB, locale string, items []CocktailIngredient) error {
+	if locale == "" || locale == LocaleRU || len(items) == 0 {
+		return nil
+	}
+
+	goodIDs := make([]int, 0, len(items))
+	for _, ing := range items {
+		goodIDs = append(goodIDs, ing.GoodID)
+	}
+	goods, err := getGoodTranslations(ctx, db, locale, goodIDs)
+	if err != nil {
+		return err
+	}
+	units, err := getUnitTranslations(ctx, db, locale)
+	if err != nil {
+		return err
+	}
+	translateIngredients(items, goods, units)
+	return nil
+}
+
+// translateIngredients — подставляет найденные переводы ингредиентов и единиц
+func translateIngredients(items []CocktailIngredient, goods map[int]string, units map[string]string) {
+	for i := range items {
+		ing := &items[i]
+		if name, ok := goods[ing.GoodID]; ok {
+			ing.Good.Name = name
+		}
+		if unit, ok := units[ing.Unit]; ok {
+			ing.Unit = unit
+		}
+	}
+}
+
+// TranslateGoodNames — переводы ингредиентов по русскому названию (списки
+// недостающего в /make): название → перевод. Чего нет в переводе, в карте нет
+func TranslateGoodNames(ctx context.Context, db *sql.DB, locale string, names []string) (map[string]string, error) {
+	result := make(map[string]string)
+	if locale == "" || locale == LocaleRU || len(names) == 0 {
+		return result, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT g.name, t.name
+		FROM goods g JOIN good_translations t ON t.good_id = g.id
+		WHERE t.locale = $1 AND g.name = ANY($2);
+	`, locale, pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, translated string
+		if err := rows.Scan(&name, &translated); err != nil {
+			return nil, err
+		}
+		result[name] = translated
+	}
+	return result, nil
+}
+
+// getGoodTranslations — переводы ингредиентов: good_id → название
+func getGoodTranslations(ctx context.Context, db *sql.DB, locale string, goodIDs []int) (map[int]string, error) {
+	result := make(map[int]string)
+	if len(goodIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT good_id, name FROM good_translations
+		WHERE locale = $1 AND good_id = ANY($2);
+	`, locale, pq.Array(goodIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		result[id] = name
+	}
+	return result, nil
+}
+
+// getUnitTranslations — переводы единиц измерения: "мл" → "ml"
+func getUnitTranslations(ctx context.Context, db *sql.DB, locale string) (map[string]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT unit, name FROM unit_translations WHERE locale = $1;
+	`, locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]string)
+	for rows.Next() {
+		var unit, name string
+		if err := rows.Scan(&unit, &name); err != nil {
+			return nil, err
+		}
+		result[unit] = name
+	}
+	return result, nil
+}
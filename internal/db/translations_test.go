@@ -0,0 +1,23 @@
+package db
+
+import "testing"
+
+func TestRecipeKey(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://ru.inshaker.com/cocktails/35-mohito", "35"},
+		{"https://en.inshaker.com/cocktails/35-mojito", "35"},
+		{"https://inshaker.com/cocktails/1024", "1024"},
+		{"https://ru.inshaker.com/cocktails/7-negroni?utm=bot", "7"},
+		{"https://ru.inshaker.com/cocktails/mohito", ""},
+		{"https://ru.inshaker.com/goods/35-rom", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := RecipeKey(tt.url); got != tt.want {
+			t.Errorf("RecipeKey(%q) = %q; want %q", tt.url, got, tt.want)
+		}
+	}
+}
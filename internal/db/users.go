@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// UpsertUser — создаёт пользователя или обновляет профиль и время активности.
+// Раз пользователь снова пишет боту в личку (private), значит он больше не блокирует
+// бота и бот может писать ему первым (started); сообщения в группах об этом ничего не говорят.
+// Возвращает код языка интерфейса: выбранный через /lang или из Telegram
+func UpsertUser(ctx context.Context, db *sql.DB, u User, private bool) (string, error) {
+	var lang string
+	err := db.QueryRowContext(ctx, `
+		INSERT INTO users (id, username, first_name, language_code, started)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE
+		    SET username = EXCLUDED.username,
+		        first_name = EXCLUDED.first_name,
+		        language_code = EXCLUDED.language_code,
+		        last_active = NOW(),
+		        blocked = users.blocked AND NOT $5,
+		        started = users.started OR $5
+		RETURNING COALESCE(NULLIF(language, ''), language_code);
+	`, u.ID, u.Username, u.FirstName, u.LanguageCode, private).Scan(&lang)
+	return lang, err
+}
+
+// SetUserBlocked — отметить, что пользователь заблокировал бота (или разблокировал)
+func SetUserBlocked(ctx context.Context, db *sql.DB, userID int64, blocked bool) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE users SET blocked = $2 WHERE id = $1;
+	`, userID, blocked)
+	return err
+}
+
+// GetUser — профиль пользователя
+func GetUser(ctx context.Context, db *sql.DB, userID int64) (User, error) {
+	var u User
+	err := db.QueryRowContext(ctx, `
+		SELECT id, username, first_name, language_code, language, first_seen, last_active, blocked
+		FROM users
+		WHERE id = $1;
+	`, userID).Scan(&u.ID, &u.Username, &u.FirstName, &u.LanguageCode, &u.Language, &u.FirstSeen, &u.LastActive, &u.Blocked)
+	return u, err
+}
+
+// SetUserLanguage — сохранить язык интерфейса, выбранный пользователем
+func SetUserLanguage(ctx context.Context, db *sql.DB, userID int64, lang string) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE users SET language = $2 WHERE id = $1;
+	`, userID, lang)
+	return err
+}
+
+// GetUserLanguage — код языка интерфейса пользователя (для сообщений вне апдейтов).
+// Пустая строка, если пользователь не найден
+func GetUserLanguage(ctx context.Context, db *sql.DB, userID int64) (string, error) {
+	var lang string
+	err := db.QueryRowContext(ctx, `
+		SELECT COALESCE(NULLIF(language, ''), language_code)
+		FROM users
+		WHERE id = $1;
+	`, userID).Scan(&lang)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return lang, err
+}
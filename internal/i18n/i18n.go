@@ -0,0 +1,93 @@
+// Package i18n — каталог сообщений бота и правила множественного числа.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lang — язык интерфейса
+type Lang string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+
+	Default = RU
+)
+
+// Supported — языки, для которых есть каталог
+func Supported() []Lang {
+	return []Lang{RU, EN}
+}
+
+// Resolve — язык по коду из Telegram или пользовательской настройке ("ru", "en-US").
+// Пустой код — язык по умолчанию, русскоязычные соседи — русский, прочие — английский
+func Resolve(code string) Lang {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" {
+		return Default
+	}
+	base, _, _ := strings.Cut(code, "-")
+	switch base {
+	case "ru", "uk", "be", "kk":
+		return RU
+	case "en":
+		return EN
+	}
+	return EN
+}
+
+// T — сообщение по ключу; args подставляются как в fmt.Sprintf.
+// Если перевода нет, берётся язык по умолчанию, затем сам ключ
+func T(l Lang, key string, args ...any) string {
+	tmpl, ok := messages[l][key]
+	if !ok {
+		tmpl, ok = messages[Default][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return tmpl
+	}
+	return fmt.Sprintf(tmpl, args...)
+}
+
+// N — сообщение с числом: форма выбирается по n (1 рецепт / 2 рецепта / 5 рецептов).
+// n подставляется первым аргументом, за ним — args
+func N(l Lang, key string, n int, args ...any) string {
+	forms, ok := plurals[l][key]
+	if !ok {
+		l = Default
+		forms, ok = plurals[l][key]
+	}
+	if !ok {
+		return key
+	}
+	return fmt.Sprintf(forms[pluralForm(l, n)], append([]any{n}, args...)...)
+}
+
+// pluralForm — индекс формы: для русского 0 — один, 1 — несколько, 2 — много;
+// для английского 0 — один, 1 — остальные
+func pluralForm(l Lang, n int) int {
+	if n < 0 {
+		n = -n
+	}
+	switch l {
+	case RU:
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return 0
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return 1
+		default:
+			return 2
+		}
+	default:
+		if n == 1 {
+			return 0
+		}
+		return 1
+	}
+}
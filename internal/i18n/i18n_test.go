@@ -0,0 +1,73 @@
+package i18n
+
+import "testing"
+
+func TestPluralForm(t *testing.T) {
+	tests := []struct {
+		lang Lang
+		n    int
+		want int
+	}{
+		{RU, 0, 2},
+		{RU, 1, 0},
+		{RU, 2, 1},
+		{RU, 4, 1},
+		{RU, 5, 2},
+		{RU, 11, 2},
+		{RU, 12, 2},
+		{RU, 14, 2},
+		{RU, 21, 0},
+		{RU, 22, 1},
+		{RU, 25, 2},
+		{RU, 101, 0},
+		{RU, 111, 2},
+		{RU, -1, 0},
+		{EN, 0, 1},
+		{EN, 1, 0},
+		{EN, 2, 1},
+		{EN, 11, 1},
+		{EN, 21, 1},
+	}
+	for _, tt := range tests {
+		if got := pluralForm(tt.lang, tt.n); got != tt.want {
+			t.Errorf("pluralForm(%s, %d) = %d; want %d", tt.lang, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestN(t *testing.T) {
+	tests := []struct {
+		lang Lang
+		n    int
+		want string
+	}{
+		{RU, 1, "🛒 Список покупок на 1 порцию: Мохито"},
+		{RU, 2, "🛒 Список покупок на 2 порции: Мохито"},
+		{RU, 5, "🛒 Список покупок на 5 порций: Мохито"},
+		{RU, 11, "🛒 Список покупок на 11 порций: Мохито"},
+		{RU, 21, "🛒 Список покупок на 21 порцию: Мохито"},
+		{EN, 1, "🛒 Shopping list for 1 serving: Мохито"},
+		{EN, 21, "🛒 Shopping list for 21 servings: Мохито"},
+	}
+	for _, tt := range tests {
+		if got := N(tt.lang, "shop.header", tt.n, "Мохито"); got != tt.want {
+			t.Errorf("N(%s, shop.header, %d) = %q; want %q", tt.lang, tt.n, got, tt.want)
+		}
+	}
+
+	if got := N(EN, "no.such.key", 3); got != "no.such.key" {
+		t.Errorf("N() для неизвестного ключа = %q; want сам ключ", got)
+	}
+}
+
+// каждому сообщению с числом нужны все формы языка, иначе N выйдет за границы
+func TestPluralCatalogue(t *testing.T) {
+	forms := map[Lang]int{RU: 3, EN: 2}
+	for _, l := range Supported() {
+		for key, f := range plurals[l] {
+			if len(f) != forms[l] {
+				t.Errorf("plurals[%s][%q]: %d форм; want %d", l, key, len(f), forms[l])
+			}
+		}
+	}
+}
@@ -0,0 +1,426 @@
+package i18n
+
+// messages — шаблоны сообщений по языкам (формат fmt)
+var messages = map[Lang]map[string]string{
+	RU: {
+		// общие
+		"err.db":               "❌ Ошибка при обращении к базе. Попробуй позже.",
+		"err.search":           "❌ Ошибка при поиске рецептов.",
+		"err.similar_goods":    "⚠️ Ошибка поиска похожих ингредиентов.",
+		"ingredient.not_found": "🥲 Такой ингредиент не найден. Попробуй другой.",
+		"list.item":            "• %s",
+		"start": "👋 Привет! Я помогу подобрать коктейль.\n\n" +
+			"Напиши, какой ингредиент хочешь использовать 🍋🥃",
+
+		// меню после выбора ингредиента
+		"menu.show":      "👀 Показать",
+		"menu.add":       "➕ Добавить ингредиент",
+		"menu.favorites": "⭐ Избранное",
+		"menu.clear":     "🧹 Очистить ингредиенты",
+
+		// свободный текст и ингредиенты
+		"text.ambiguous":           "🤔 Ты ищешь коктейль или ингредиент?",
+		"text.similar_names":       "🔎 Похожие названия:",
+		"ingredient.suggest":       "🤔 Возможно, вы имели в виду:",
+		"ingredient.none_of_these": "🙅 Ничего из этого",
+		"ingredient.searching":     "🔍 Ищу рецепты с ингредиентом: %s...",
+		"ingredient.retry":         "Окей 🙂 напиши ингредиент ещё раз:",
+		"ingredient.no_cocktails":  "🥲 Коктейлей с таким ингредиентом не найдено.",
+
+		// карточка и действия с ней
+		"card.not_found":   "🥲 Коктейль не найден.",
+		"card.rating":      "Твоя оценка: %s",
+		"card.substitute":  "   ↔ замена: %s",
+		"btn.fav":          "💛 В избранное",
+		"btn.ignore":       "🚫 Скрыть",
+		"btn.rate":         "⭐ Оценить",
+		"btn.note":         "📝 Заметка",
+		"btn.similar":      "🔗 Похожие",
+		"fav.add_failed":   "❌ Не удалось добавить в избранное.",
+		"fav.added":        "💛 Добавлено в избранное!",
+		"ignore.failed":    "❌ Ошибка при скрытии коктейля.",
+		"ignore.done":      "🚫 Коктейль скрыт.",
+		"rate.prompt":      "⭐ Оцени коктейль:",
+		"rate.failed":      "❌ Не удалось сохранить оценку.",
+		"rate.saved":       "⭐ Оценка сохранена: %s",
+		"note.prompt":      "📝 Напиши заметку к коктейлю (например, «меньше сиропа»). Чтобы удалить заметку, отправь «-».",
+		"note.save_failed": "❌ Не удалось сохранить заметку.",
+		"note.deleted":     "🗑 Заметка удалена.",
+		"note.saved":       "📝 Заметка сохранена.",
+		"next.todo":        "⏭ Показать следующий пока не реализовано 🙂",
+		"similar.none":     "🤷 Похожих коктейлей не нашлось.",
+		"similar.title":    "🔗 Похожие коктейли:",
+		"similar.item":     "• %s — %d%%",
+
+		// избранное
+		"fav.empty":     "💛 В избранном пока пусто.",
+		"fav.title":     "💛 Избранное:",
+		"fav.by_date":   "🕒 По дате добавления",
+		"fav.by_rating": "⭐ По оценке",
+
+		// /find и /search
+		"find.usage":     "🔎 Перечисли ингредиенты через запятую: /find лайм, ром, мята",
+		"find.not_found": "🥲 Такие ингредиенты не найдены. Попробуй другие.",
+		"find.none":      "🥲 Коктейлей с такими ингредиентами не найдено.",
+		"find.title":     "🍸 Лучшие совпадения по: %s",
+		"find.unknown":   "🤷 Не нашёл: %s",
+		"find.explain":   "совпало %d из %d: %s · %d из %d в рецепте",
+		"search.usage":   "🔎 Что ищем? Например:\n/search лайм мята\n/search лайм -ром\n/search \"сахарный сироп\"",
+		"search.none":    "🥲 Ничего не нашлось. Попробуй другие слова.",
+		"search.title":   "🔎 Результаты по запросу «%s»:",
+
+		// домашний бар
+		"bar.add_failed":    "❌ Не удалось добавить в бар.",
+		"bar.added":         "🍾 Добавлено в бар: %s",
+		"bar.unknown":       "🥲 Не нашёл: %s",
+		"good.category":     "🗂 «%s» — это группа ингредиентов. Добавь конкретные, например: %s",
+		"bar.empty_hint":    "🍾 Твой бар пуст. Добавь ингредиенты: /bar ром, лайм, сахарный сироп",
+		"bar.remove_failed": "❌ Не удалось убрать",
+		"bar.removed":       "Убрано из бара",
+		"bar.empty":         "🍾 Твой бар пуст.",
+		"make.nothing":      "🥲 Из твоего бара пока ничего не собрать. Добавь ингредиенты: /bar",
+		"make.missing":      "• %s — нет: %s",
+		"make.with_subs":    "🔁 С учётом замен ингредиентов",
+		"make.ready":        "✅ Можно приготовить:",
+		"make.one":          "🟡 Не хватает одного:",
+		"make.two":          "🟠 Не хватает двух:",
+		"make.subs_button":  "🔁 С учётом замен",
+
+		// /random
+		"random.more":  "🎲 Ещё",
+		"random.cycle": "🔁 Ты посмотрел все подходящие коктейли — начинаем сначала.",
+		"random.none":  "🥲 Под такие условия ничего не нашлось.",
+
+		// /recommend
+		"recommend.empty":   "💛 Добавь пару коктейлей в избранное — и я подберу похожие на твой вкус.",
+		"recommend.title":   "✨ Тебе может понравиться:",
+		"recommend.because": "• %s — потому что тебе понравился %s",
+
+		// /daily
+		"daily.usage": "🌅 Коктейль дня: /daily 18:00 — каждый день в 18:00 по Москве.\n" +
+			"Другой часовой пояс: /daily 18:00 Asia/Yekaterinburg",
+		"daily.status":       "🌅 Коктейль дня приходит в %s (%s). Отписаться: /daily off",
+		"daily.unsub_failed": "❌ Не удалось отписаться.",
+		"daily.unsubscribed": "👌 Подписка на коктейль дня отменена.",
+		"daily.bad_time":     "🤔 Не понял время. Пример: /daily 18:00",
+		"daily.bad_tz":       "🤔 Не знаю такой часовой пояс. Пример: Europe/Moscow, Asia/Novosibirsk",
+		"daily.sub_failed":   "❌ Не удалось оформить подписку.",
+		"daily.subscribed":   "✅ Буду присылать коктейль дня в %s (%s).",
+		"daily.header":       "🌅 Коктейль дня:",
+
+		// /shopping
+		"shop.bad_servings":  "🤔 Число порций должно быть от 1 до %d.",
+		"shop.no_candidates": "🛒 Выбирать не из чего: добавь коктейли в избранное или найди их по ингредиенту.",
+		"shop.select":        "🛒 Отметь коктейли для вечеринки и число порций:",
+		"shop.expired":       "⌛ Список устарел, начни заново: /shopping",
+		"shop.select_one":    "☝️ Сначала отметь хотя бы один коктейль.",
+		"shop.have":          "🏠 Отметь то, что уже есть дома, и нажми «Готово»:",
+		"shop.all_have":      "🎉 Всё уже есть — можно начинать!",
+		"shop.to_taste":      "по вкусу",
+		"shop.next":          "Далее ➡️",
+		"shop.done":          "🧾 Готово",
+
+		// /alias
+		"admin.only":   "⛔ Команда доступна только администраторам.",
+		"alias.usage":  "✍️ Формат: /alias водочка = Водка",
+		"alias.failed": "❌ Не удалось добавить синоним: %v",
+		"alias.added":  "✅ «%s» теперь означает «%s»",
+
+		// /admin
+		"admin.help": "🛠 Админка:\n" +
+			"/admin scrape — перепарсить Inshaker\n" +
+			"/admin status — состояние парсинга\n" +
+			"/admin stats — статистика базы\n" +
+			"/admin aliases — перезагрузить синонимы\n" +
+			"/admin errors — последние ошибки\n" +
+			"/admin audit — журнал действий\n" +
+			"/broadcast текст — рассылка всем пользователям",
+		"admin.unknown":           "🤔 Не знаю такой команды.",
+		"admin.scrape_started":    "🔄 Парсинг запущен. Состояние: /admin status",
+		"admin.scrape_running":    "⏳ Парсинг уже идёт. Состояние: /admin status",
+		"admin.status_never":      "ℹ️ С момента запуска бота парсинг не запускался.",
+		"admin.status_running":    "⏳ Парсинг идёт с %s. Собрано рецептов: %d",
+		"admin.status_done":       "✅ Последний парсинг: %s – %s (%v). Рецептов: %d",
+		"admin.status_failed":     "❌ Парсинг, начатый %s, завершился ошибкой: %v",
+		"admin.status_translated": "🌐 %s: сопоставлено рецептов %d",
+		"admin.stats": "📊 Коктейлей: %d (с переводом: %d)\n" +
+			"Ингредиентов: %d, синонимов: %d\n" +
+			"Пользователей: %d (заблокировали бота: %d)\n" +
+			"В избранном: %d",
+		"admin.aliases_reloaded": "✅ Синонимы перезагружены. Всего: %d",
+		"admin.errors_none":      "✨ Ошибок не было.",
+		"admin.errors_title":     "🧯 Последние ошибки:",
+		"admin.audit_none":       "📜 Журнал пуст.",
+		"admin.audit_title":      "📜 Последние действия:",
+		"admin.btn_scrape":       "🔄 Парсинг",
+		"admin.btn_status":       "⏳ Статус",
+		"admin.btn_stats":        "📊 Статистика",
+		"admin.btn_aliases":      "🔤 Синонимы",
+		"admin.btn_errors":       "🧯 Ошибки",
+		"admin.btn_audit":        "📜 Журнал",
+
+		// группы и общий бар вечеринки
+		"group.start": "👋 Всем привет! Я помогу собрать бар для вечеринки.\n\n" +
+			"Пишите, кто что принесёт: /bring ром, лайм — или просто «принесу ром».\n" +
+			"Общий бар и что из него приготовить: /party",
+		"group.private_command": "🔒 /%s — личная команда. Напиши мне в личку: @%s",
+		"group.private_button":  "🔒 Эта кнопка работает в личном чате с ботом",
+		"party.group_only":      "👥 Эта команда работает в групповых чатах. Для своего бара — /bar",
+		"party.bring_usage":     "✍️ Формат: /bring ром, лайм",
+		"party.brings":          "🎉 %s принесёт: %s",
+		"party.hint":            "🥳 Общий бар: /party",
+		"party.title":           "🥳 Бар вечеринки:",
+		"party.item":            "• %s — %s",
+		"party.empty":           "🥳 Бар вечеринки пока пуст. Кто что принесёт? /bring ром, лайм",
+		"party.btn_make":        "🍹 Что приготовим?",
+		"party.btn_clear":       "🧹 Очистить",
+		"party.removed":         "Убрано из бара вечеринки",
+		"party.cleared":         "🧹 Бар вечеринки очищен.",
+		"party.nothing":         "🥲 Из общего бара пока ничего не собрать. Кто принесёт ещё? /bring",
+		"party.make_title":      "🥳 Что может приготовить компания:",
+
+		// inline-режим
+		"inline.hint":       "🍸 Напиши название коктейля или ингредиент",
+		"inline.btn_recipe": "🔗 Рецепт на Inshaker",
+		"inline.btn_more":   "🔍 Найти коктейль",
+
+		// /broadcast
+		"broadcast.usage":      "✍️ Формат: /broadcast текст объявления",
+		"broadcast.btn_send":   "✅ Отправить",
+		"broadcast.btn_cancel": "❌ Отмена",
+		"broadcast.started":    "📣 Рассылка #%d запущена. Отчёт придёт по окончании.",
+		"broadcast.cancelled":  "🗑 Рассылка отменена.",
+		"broadcast.not_draft":  "ℹ️ Эта рассылка уже запущена или отменена.",
+		"broadcast.report":     "📣 Рассылка #%d завершена.\nДоставлено: %d\nНе доставлено: %d",
+
+		// /lang
+		"lang.prompt": "🌐 Выбери язык:",
+		"lang.set":    "✅ Язык интерфейса: русский",
+		"lang.name":   "🇷🇺 Русский",
+	},
+
+	EN: {
+		"err.db":               "❌ Database error. Please try again later.",
+		"err.search":           "❌ Error while searching for recipes.",
+		"err.similar_goods":    "⚠️ Error while looking for similar ingredients.",
+		"ingredient.not_found": "🥲 Ingredient not found. Try another one.",
+		"list.item":            "• %s",
+		"start": "👋 Hi! I'll help you pick a cocktail.\n\n" +
+			"Type an ingredient you'd like to use 🍋🥃",
+
+		"menu.show":      "👀 Show",
+		"menu.add":       "➕ Add ingredient",
+		"menu.favorites": "⭐ Favorites",
+		"menu.clear":     "🧹 Clear ingredients",
+
+		"text.ambiguous":           "🤔 Are you looking for a cocktail or an ingredient?",
+		"text.similar_names":       "🔎 Similar names:",
+		"ingredient.suggest":       "🤔 Did you mean:",
+		"ingredient.none_of_these": "🙅 None of these",
+		"ingredient.searching":     "🔍 Looking for recipes with: %s...",
+		"ingredient.retry":         "Okay 🙂 type the ingredient again:",
+		"ingredient.no_cocktails":  "🥲 No cocktails with this ingredient.",
+
+		"card.not_found":   "🥲 Cocktail not found.",
+		"card.rating":      "Your rating: %s",
+		"card.substitute":  "   ↔ substitute: %s",
+		"btn.fav":          "💛 Favorite",
+		"btn.ignore":       "🚫 Hide",
+		"btn.rate":         "⭐ Rate",
+		"btn.note":         "📝 Note",
+		"btn.similar":      "🔗 Similar",
+		"fav.add_failed":   "❌ Couldn't add to favorites.",
+		"fav.added":        "💛 Added to favorites!",
+		"ignore.failed":    "❌ Couldn't hide the cocktail.",
+		"ignore.done":      "🚫 Cocktail hidden.",
+		"rate.prompt":      "⭐ Rate the cocktail:",
+		"rate.failed":      "❌ Couldn't save the rating.",
+		"rate.saved":       "⭐ Rating saved: %s",
+		"note.prompt":      "📝 Write a note for this cocktail (e.g. \"less syrup\"). Send \"-\" to delete the note.",
+		"note.save_failed": "❌ Couldn't save the note.",
+		"note.deleted":     "🗑 Note deleted.",
+		"note.saved":       "📝 Note saved.",
+		"next.todo":        "⏭ \"Show next\" is not implemented yet 🙂",
+		"similar.none":     "🤷 No similar cocktails found.",
+		"similar.title":    "🔗 Similar cocktails:",
+		"similar.item":     "• %s — %d%%",
+
+		"fav.empty":     "💛 Your favorites list is empty.",
+		"fav.title":     "💛 Favorites:",
+		"fav.by_date":   "🕒 By date added",
+		"fav.by_rating": "⭐ By rating",
+
+		"find.usage":     "🔎 List ingredients separated by commas: /find lime, rum, mint",
+		"find.not_found": "🥲 None of these ingredients were found. Try others.",
+		"find.none":      "🥲 No cocktails with these ingredients.",
+		"find.title":     "🍸 Best matches for: %s",
+		"find.unknown":   "🤷 Not found: %s",
+		"find.explain":   "matched %d of %d: %s · %d of %d in the recipe",
+		"search.usage":   "🔎 What are we looking for? For example:\n/search лайм мята\n/search лайм -ром\n/search \"сахарный сироп\"",
+		"search.none":    "🥲 Nothing found. Try other words.",
+		"search.title":   "🔎 Results for «%s»:",
+
+		"bar.add_failed":    "❌ Couldn't add to your bar.",
+		"bar.added":         "🍾 Added to your bar: %s",
+		"bar.unknown":       "🥲 Not found: %s",
+		"good.category":     "🗂 “%s” is a group of ingredients. Add specific ones, e.g. %s",
+		"bar.empty_hint":    "🍾 Your bar is empty. Add ingredients: /bar rum, lime, simple syrup",
+		"bar.remove_failed": "❌ Couldn't remove",
+		"bar.removed":       "Removed from your bar",
+		"bar.empty":         "🍾 Your bar is empty.",
+		"make.nothing":      "🥲 Nothing can be made from your bar yet. Add ingredients: /bar",
+		"make.missing":      "• %s — missing: %s",
+		"make.with_subs":    "🔁 Counting ingredient substitutes",
+		"make.ready":        "✅ Ready to make:",
+		"make.one":          "🟡 Missing one:",
+		"make.two":          "🟠 Missing two:",
+		"make.subs_button":  "🔁 Count substitutes",
+
+		"random.more":  "🎲 Another",
+		"random.cycle": "🔁 You've seen all matching cocktails — starting over.",
+		"random.none":  "🥲 Nothing matches these conditions.",
+
+		"recommend.empty":   "💛 Add a couple of cocktails to favorites and I'll find ones that suit your taste.",
+		"recommend.title":   "✨ You might like:",
+		"recommend.because": "• %s — because you liked %s",
+
+		"daily.usage": "🌅 Cocktail of the day: /daily 18:00 — every day at 18:00 Moscow time.\n" +
+			"Another time zone: /daily 18:00 Europe/London",
+		"daily.status":       "🌅 The cocktail of the day arrives at %s (%s). Unsubscribe: /daily off",
+		"daily.unsub_failed": "❌ Couldn't unsubscribe.",
+		"daily.unsubscribed": "👌 Cocktail of the day subscription cancelled.",
+		"daily.bad_time":     "🤔 I didn't get the time. Example: /daily 18:00",
+		"daily.bad_tz":       "🤔 Unknown time zone. Example: Europe/Moscow, Europe/London",
+		"daily.sub_failed":   "❌ Couldn't subscribe.",
+		"daily.subscribed":   "✅ I'll send the cocktail of the day at %s (%s).",
+		"daily.header":       "🌅 Cocktail of the day:",
+
+		"shop.bad_servings":  "🤔 Servings must be between 1 and %d.",
+		"shop.no_candidates": "🛒 Nothing to choose from: add cocktails to favorites or find them by ingredient.",
+		"shop.select":        "🛒 Pick cocktails for the party and the number of servings:",
+		"shop.expired":       "⌛ This list has expired, start over: /shopping",
+		"shop.select_one":    "☝️ Pick at least one cocktail first.",
+		"shop.have":          "🏠 Mark what you already have and press «Done»:",
+		"shop.all_have":      "🎉 You have everything — let's start!",
+		"shop.to_taste":      "to taste",
+		"shop.next":          "Next ➡️",
+		"shop.done":          "🧾 Done",
+
+		"admin.only":   "⛔ This command is for administrators only.",
+		"alias.usage":  "✍️ Usage: /alias vodka = Водка",
+		"alias.failed": "❌ Couldn't add the alias: %v",
+		"alias.added":  "✅ «%s» now means «%s»",
+
+		"admin.help": "🛠 Admin:\n" +
+			"/admin scrape — re-scrape Inshaker\n" +
+			"/admin status — scrape status\n" +
+			"/admin stats — database stats\n" +
+			"/admin aliases — reload aliases\n" +
+			"/admin errors — recent errors\n" +
+			"/admin audit — action log\n" +
+			"/broadcast text — message all users",
+		"admin.unknown":           "🤔 Unknown command.",
+		"admin.scrape_started":    "🔄 Scrape started. Status: /admin status",
+		"admin.scrape_running":    "⏳ A scrape is already running. Status: /admin status",
+		"admin.status_never":      "ℹ️ No scrape has run since the bot started.",
+		"admin.status_running":    "⏳ Scraping since %s. Recipes collected: %d",
+		"admin.status_done":       "✅ Last scrape: %s – %s (%v). Recipes: %d",
+		"admin.status_failed":     "❌ The scrape started at %s failed: %v",
+		"admin.status_translated": "🌐 %s: %d recipes matched",
+		"admin.stats": "📊 Cocktails: %d (translated: %d)\n" +
+			"Ingredients: %d, aliases: %d\n" +
+			"Users: %d (blocked the bot: %d)\n" +
+			"Favorites: %d",
+		"admin.aliases_reloaded": "✅ Aliases reloaded. Total: %d",
+		"admin.errors_none":      "✨ No errors.",
+		"admin.errors_title":     "🧯 Recent errors:",
+		"admin.audit_none":       "📜 The log is empty.",
+		"admin.audit_title":      "📜 Recent actions:",
+		"admin.btn_scrape":       "🔄 Scrape",
+		"admin.btn_status":       "⏳ Status",
+		"admin.btn_stats":        "📊 Stats",
+		"admin.btn_aliases":      "🔤 Aliases",
+		"admin.btn_errors":       "🧯 Errors",
+		"admin.btn_audit":        "📜 Log",
+
+		"group.start": "👋 Hi all! I'll help you stock the bar for your party.\n\n" +
+			"Tell me who's bringing what: /bring rum, lime — or just “I'm bringing rum”.\n" +
+			"The shared bar and what you can make from it: /party",
+		"group.private_command": "🔒 /%s is a personal command. Message me directly: @%s",
+		"group.private_button":  "🔒 This button works in a private chat with the bot",
+		"party.group_only":      "👥 This command works in group chats. For your own bar use /bar",
+		"party.bring_usage":     "✍️ Usage: /bring rum, lime",
+		"party.brings":          "🎉 %s is bringing: %s",
+		"party.hint":            "🥳 Shared bar: /party",
+		"party.title":           "🥳 Party bar:",
+		"party.item":            "• %s — %s",
+		"party.empty":           "🥳 The party bar is empty so far. Who's bringing what? /bring rum, lime",
+		"party.btn_make":        "🍹 What can we make?",
+		"party.btn_clear":       "🧹 Clear",
+		"party.removed":         "Removed from the party bar",
+		"party.cleared":         "🧹 The party bar has been cleared.",
+		"party.nothing":         "🥲 Nothing can be made from the shared bar yet. Who's bringing more? /bring",
+		"party.make_title":      "🥳 What the party can make:",
+
+		"inline.hint":       "🍸 Type a cocktail name or an ingredient",
+		"inline.btn_recipe": "🔗 Recipe on Inshaker",
+		"inline.btn_more":   "🔍 Find a cocktail",
+
+		"broadcast.usage":      "✍️ Usage: /broadcast announcement text",
+		"broadcast.btn_send":   "✅ Send",
+		"broadcast.btn_cancel": "❌ Cancel",
+		"broadcast.started":    "📣 Broadcast #%d started. You'll get a report when it's done.",
+		"broadcast.cancelled":  "🗑 Broadcast cancelled.",
+		"broadcast.not_draft":  "ℹ️ This broadcast has already been started or cancelled.",
+		"broadcast.report":     "📣 Broadcast #%d finished.\nDelivered: %d\nFailed: %d",
+
+		"lang.prompt": "🌐 Choose a language:",
+		"lang.set":    "✅ Interface language: English",
+		"lang.name":   "🇬🇧 English",
+	},
+}
+
+// plurals — сообщения с числом: формы для RU — один/несколько/много, для EN — один/остальные.
+// Число подставляется первым аргументом
+var plurals = map[Lang]map[string][]string{
+	RU: {
+		"ingredient.found": {
+			"🍸 Найден %d рецепт с ингредиентом *%s*!",
+			"🍸 Найдено %d рецепта с ингредиентом *%s*!",
+			"🍸 Найдено %d рецептов с ингредиентом *%s*!",
+		},
+		"bar.summary": {
+			"🍾 В твоём баре %d ингредиент. Нажми, чтобы убрать.\nЧто можно приготовить: /make",
+			"🍾 В твоём баре %d ингредиента. Нажми, чтобы убрать.\nЧто можно приготовить: /make",
+			"🍾 В твоём баре %d ингредиентов. Нажми, чтобы убрать.\nЧто можно приготовить: /make",
+		},
+		"shop.header": {
+			"🛒 Список покупок на %d порцию: %s",
+			"🛒 Список покупок на %d порции: %s",
+			"🛒 Список покупок на %d порций: %s",
+		},
+		"broadcast.preview": {
+			"👀 Предпросмотр выше. Рассылку получит %d пользователь — отправить?",
+			"👀 Предпросмотр выше. Рассылку получат %d пользователя — отправить?",
+			"👀 Предпросмотр выше. Рассылку получат %d пользователей — отправить?",
+		},
+	},
+	EN: {
+		"ingredient.found": {
+			"🍸 Found %d recipe with *%s*!",
+			"🍸 Found %d recipes with *%s*!",
+		},
+		"bar.summary": {
+			"🍾 Your bar has %d ingredient. Tap to remove.\nWhat can I make: /make",
+			"🍾 Your bar has %d ingredients. Tap to remove.\nWhat can I make: /make",
+		},
+		"shop.header": {
+			"🛒 Shopping list for %d serving: %s",
+			"🛒 Shopping list for %d servings: %s",
+		},
+		"broadcast.preview": {
+			"👀 Preview above. %d user will receive it — send?",
+			"👀 Preview above. %d users will receive it — send?",
+		},
+	},
+}
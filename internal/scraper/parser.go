@@ -1,9 +1,12 @@
 package scraper
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -16,33 +19,80 @@ const (
 	pageDelay            = 1200 * time.Millisecond // пауза между страницами
 	detailDelay          = 300 * time.Millisecond  // пауза между запросами к рецептам
 	requestTimeout       = 20 * time.Second
-	baseHost             = "https://ru.inshaker.com"
 	listItemSelector     = "a.cocktail-item-preview"
 	ingredientSelector   = "dl.ingredients dd.good"
 	instructionsSelector = ".how-to-make"
+	tagSelector          = ".tags a"
 )
 
 var httpClient = &http.Client{Timeout: requestTimeout}
 
-// ParseRecipes — парсит все рецепты со страниц ?random_page=
-func ParseRecipes(baseURL string) ([]db.Cocktail, error) {
+// Sites — языковые версии Inshaker. Русская — основная: из неё строятся
+// cocktails и goods, остальные сохраняются как переводы
+var Sites = map[string]string{
+	db.LocaleRU: "https://ru.inshaker.com/cocktails",
+	"en":        "https://en.inshaker.com/cocktails",
+}
+
+// Run — полный парсинг: русские рецепты, затем переводы с остальных версий сайта
+func Run(ctx context.Context, database *sql.DB) error {
+	recipes, err := ParseRecipes(ctx, Sites[db.LocaleRU])
+	if err != nil {
+		return err
+	}
+	setRecipes(len(recipes))
+	if err := db.SaveRecipes(ctx, database, recipes); err != nil {
+		return err
+	}
+
+	for locale, siteURL := range Sites {
+		if locale == db.LocaleRU {
+			continue
+		}
+		translated, err := ParseRecipes(ctx, siteURL)
+		if err != nil {
+			log.Printf("⚠️ Ошибка парсинга версии %s: %v", locale, err)
+			continue
+		}
+		matched, err := db.SaveTranslations(ctx, database, locale, translated)
+		if err != nil {
+			log.Printf("⚠️ Ошибка сохранения переводов %s: %v", locale, err)
+		}
+		setTranslated(locale, matched)
+	}
+	return nil
+}
+
+// ParseRecipes — парсит все рецепты со страниц ?random_page=.
+// Ссылки строятся от хоста baseURL, поэтому подходит для любой языковой версии.
+// Отмена ctx прерывает обход и возвращает ctx.Err()
+func ParseRecipes(ctx context.Context, baseURL string) ([]db.Cocktail, error) {
 	log.Println("🔍 Запуск постраничного парсинга по random_page:", baseURL)
 
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	host := u.Scheme + "://" + u.Host
+
 	all := make([]db.Cocktail, 0, 1200)
 	seen := make(map[string]struct{})
 	emptyCount := 0
 
 	for page := 1; page <= maxPages; page++ {
-		url := fmt.Sprintf("%s?random_page=%d", baseURL, page)
-		log.Printf("📄 Страница %d → %s", page, url)
+		pageURL := fmt.Sprintf("%s?random_page=%d", baseURL, page)
+		log.Printf("📄 Страница %d → %s", page, pageURL)
 
-		doc, err := fetchDoc(url)
+		doc, err := fetchDoc(ctx, pageURL)
+		if ctx.Err() != nil {
+			return all, ctx.Err()
+		}
 		if err != nil {
 			log.Printf("⚠️ Ошибка загрузки страницы %d: %v", page, err)
 			continue
 		}
 
-		pageCocktails := parseCocktailList(doc, seen)
+		pageCocktails := parseCocktailList(ctx, doc, host, seen)
 		log.Printf("✅ Страница %d — собрано %d рецептов (итого: %d)", page, len(pageCocktails), len(all))
 
 		if len(pageCocktails) == 0 {
@@ -56,16 +106,30 @@ func ParseRecipes(baseURL string) ([]db.Cocktail, error) {
 			all = append(all, pageCocktails...)
 		}
 
-		time.Sleep(pageDelay)
+		if err := sleep(ctx, pageDelay); err != nil {
+			return all, err
+		}
 	}
 
 	log.Printf("🍸 Всего собрано рецептов: %d", len(all))
 	return all, nil
 }
 
+// sleep — пауза между запросами, прерываемая отменой ctx
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
 // fetchDoc — получает и разбирает HTML
-func fetchDoc(url string) (*goquery.Document, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func fetchDoc(ctx context.Context, url string) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -85,26 +149,29 @@ func fetchDoc(url string) (*goquery.Document, error) {
 }
 
 // parseCocktailList — извлекает карточки коктейлей со страницы
-func parseCocktailList(doc *goquery.Document, seen map[string]struct{}) []db.Cocktail {
+func parseCocktailList(ctx context.Context, doc *goquery.Document, host string, seen map[string]struct{}) []db.Cocktail {
 	var cocktails []db.Cocktail
 
-	doc.Find(listItemSelector).Each(func(_ int, s *goquery.Selection) {
+	doc.Find(listItemSelector).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if ctx.Err() != nil {
+			return false
+		}
 		name := strings.TrimSpace(s.Find(".cocktail-item-name").Text())
 		href, _ := s.Attr("href")
 		img, _ := s.Find("img.cocktail-item-image").Attr("src")
 
 		if name == "" || href == "" {
-			return
+			return true
 		}
 
-		cocktailURL := baseHost + href
+		cocktailURL := host + href
 		if _, ok := seen[cocktailURL]; ok {
-			return
+			return true
 		}
 
 		imageURL := ""
 		if strings.HasPrefix(img, "/") {
-			imageURL = baseHost + img
+			imageURL = host + img
 		}
 
 		c := db.Cocktail{
@@ -113,23 +180,23 @@ func parseCocktailList(doc *goquery.Document, seen map[string]struct{}) []db.Coc
 			ImageURL: imageURL,
 		}
 
-		full, err := parseCocktailDetails(c)
+		full, err := parseCocktailDetails(ctx, c, host)
 		if err != nil {
 			log.Printf("⚠️ Ошибка деталей [%s]: %v", c.Name, err)
-			return
+			return true
 		}
 
 		cocktails = append(cocktails, full)
 		seen[cocktailURL] = struct{}{}
-		time.Sleep(detailDelay)
+		return sleep(ctx, detailDelay) == nil
 	})
 
 	return cocktails
 }
 
-// parseCocktailDetails — парсит ингредиенты и инструкцию рецепта
-func parseCocktailDetails(c db.Cocktail) (db.Cocktail, error) {
-	doc, err := fetchDoc(c.URL)
+// parseCocktailDetails — парсит ингредиенты, теги и инструкцию рецепта
+func parseCocktailDetails(ctx context.Context, c db.Cocktail, host string) (db.Cocktail, error) {
+	doc, err := fetchDoc(ctx, c.URL)
 	if err != nil {
 		return c, err
 	}
@@ -146,7 +213,7 @@ func parseCocktailDetails(c db.Cocktail) (db.Cocktail, error) {
 			end := strings.Index(styleAttr, ");")
 			if start != -1 && end != -1 && end > start+4 {
 				path := styleAttr[start+4 : end]
-				imageURL = baseHost + strings.Trim(path, "'\"")
+				imageURL = host + strings.Trim(path, "'\"")
 			}
 		}
 
@@ -164,6 +231,12 @@ func parseCocktailDetails(c db.Cocktail) (db.Cocktail, error) {
 		})
 	})
 
+	doc.Find(tagSelector).Each(func(_ int, s *goquery.Selection) {
+		if tag := strings.TrimSpace(s.Text()); tag != "" {
+			c.Tags = append(c.Tags, tag)
+		}
+	})
+
 	c.Instructions = strings.TrimSpace(doc.Find(instructionsSelector).Text())
 	return c, nil
 }
@@ -0,0 +1,77 @@
+package scraper
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+)
+
+// Status — состояние последнего парсинга
+type Status struct {
+	Running    bool
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Recipes    int            // сколько русских рецептов собрано
+	Translated map[string]int // сколько рецептов сопоставлено по языкам
+	Err        error
+}
+
+var status = struct {
+	sync.Mutex
+	Status
+}{}
+
+// GetStatus — копия текущего состояния парсинга
+func GetStatus() Status {
+	status.Lock()
+	defer status.Unlock()
+	s := status.Status
+	s.Translated = make(map[string]int, len(status.Translated))
+	for locale, n := range status.Translated {
+		s.Translated[locale] = n
+	}
+	return s
+}
+
+// Start — запускает Run в фоне. false, если парсинг уже идёт
+func Start(ctx context.Context, database *sql.DB) bool {
+	status.Lock()
+	if status.Running {
+		status.Unlock()
+		return false
+	}
+	status.Status = Status{Running: true, StartedAt: time.Now(), Translated: make(map[string]int)}
+	status.Unlock()
+
+	go func() {
+		err := Run(ctx, database)
+		if err != nil {
+			log.Printf("❌ Ошибка парсинга: %v", err)
+		}
+
+		status.Lock()
+		status.Running = false
+		status.FinishedAt = time.Now()
+		status.Err = err
+		status.Unlock()
+	}()
+	return true
+}
+
+// setRecipes, setTranslated — прогресс для GetStatus
+func setRecipes(n int) {
+	status.Lock()
+	status.Recipes = n
+	status.Unlock()
+}
+
+func setTranslated(locale string, n int) {
+	status.Lock()
+	if status.Translated == nil {
+		status.Translated = make(map[string]int)
+	}
+	status.Translated[locale] = n
+	status.Unlock()
+}